@@ -12,56 +12,63 @@
 package main
 
 import (
-	"elimu-go/internal/middleware"
-	"log"
+	"context"
+	"flag"
+	"log/slog"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	_ "elimu-go/docs"
-	"elimu-go/internal/handlers"
-
-	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
-	swaggerFiles "github.com/swaggo/files"
-	ginSwagger "github.com/swaggo/gin-swagger"
+	"elimu-go/internal/config"
+	"elimu-go/internal/logging"
+	"elimu-go/internal/server"
+	"elimu-go/internal/tracing"
 )
 
 func main() {
-	if err := godotenv.Load(); err != nil {
-		log.Fatal("Failed to load .env")
+	configFile := flag.String("config", "", "optional YAML config file (defaults to $CONFIG_FILE)")
+	flag.Parse()
+
+	cfg, err := config.Load(*configFile)
+	if err != nil {
+		fatal(err)
 	}
 
-	port := os.Getenv("PORT")
-	if port == "" {
-		port = "8080"
+	level, err := logging.ParseLevel(cfg.LogLevel)
+	if err != nil {
+		fatal(err)
 	}
+	slog.SetDefault(logging.New(os.Stdout, level))
+	slog.Info("config loaded", "config", cfg)
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	handlers.InitDB()
+	shutdownTracing, err := tracing.Setup(ctx, cfg.Tracing, cfg.Env)
+	if err != nil {
+		fatal(err)
+	}
 
-	r := gin.Default()
-	r.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	srv, err := server.NewServer(ctx, cfg)
+	if err != nil {
+		fatal(err)
+	}
 
-	api := r.Group("/api")
-	{
-		api.GET("/", handlers.Welcome)
-		api.GET("/health", handlers.HealthCheck)
-		api.GET("/random", handlers.RandomEndpoint)
-		api.GET("/debug", handlers.DebugInfo)
-		api.GET("/login", handlers.GoogleLogin)
-		api.GET("/callback", handlers.GoogleCallback)
-		api.GET("/me", handlers.GetCurrentUser)
-		api.GET("/logout", handlers.Logout)
+	err = srv.Run(ctx)
 
+	flushCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := shutdownTracing(flushCtx); err != nil {
+		slog.Warn("failed to flush traces", "error", err)
 	}
 
-	admin := api.Group("/admin")
-	admin.Use(
-		middleware.RequireLogin(handlers.Sessions),
-		middleware.RequireRole("admin", "cto"),
-	)
-	{
-		admin.GET("/overview", handlers.AdminOverview)
+	if err != nil {
+		fatal(err)
 	}
+}
 
-	log.Printf("Starting :%s", port)
-	r.Run(":" + port)
+func fatal(err error) {
+	slog.Error("api stopped", "error", err)
+	os.Exit(1)
 }
@@ -0,0 +1,97 @@
+// Command migrate applies, rolls back and reports schema migrations.
+//
+//	go run ./cmd/migrate up
+//	go run ./cmd/migrate down [steps]
+//	go run ./cmd/migrate status
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+
+	"elimu-go/database"
+	"elimu-go/internal/config"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: migrate up | down [steps] | status")
+	os.Exit(2)
+}
+
+func main() {
+	if len(os.Args) < 2 {
+		usage()
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.DatabaseURL == "" {
+		log.Fatal("DB_CONNECTION not set")
+	}
+
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL.Value())
+	if err != nil {
+		log.Fatal("Failed to connect to DB:", err)
+	}
+	defer conn.Close(ctx)
+
+	m, err := database.NewMigrator(conn)
+	if err != nil {
+		log.Fatal("Failed to load migrations:", err)
+	}
+
+	switch os.Args[1] {
+	case "up":
+		applied, err := m.Up(ctx)
+		for _, mig := range applied {
+			log.Printf("applied %04d_%s", mig.Version, mig.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+		if len(applied) == 0 {
+			log.Println("Database is up to date")
+		}
+
+	case "down":
+		steps := 1
+		if len(os.Args) > 2 {
+			steps, err = strconv.Atoi(os.Args[2])
+			if err != nil || steps < 1 {
+				usage()
+			}
+		}
+		reverted, err := m.Down(ctx, steps)
+		for _, mig := range reverted {
+			log.Printf("reverted %04d_%s", mig.Version, mig.Name)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	case "status":
+		status, err := m.Status(ctx)
+		for _, s := range status {
+			applied := "pending"
+			if s.AppliedAt != nil {
+				applied = s.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Printf("%04d_%-30s %s\n", s.Version, s.Name, applied)
+		}
+		if err != nil {
+			log.Fatal(err)
+		}
+
+	default:
+		usage()
+	}
+}
@@ -0,0 +1,92 @@
+// Command seed loads fixture users, courses, terms, offerings and
+// enrollments into the database.
+//
+//	go run ./cmd/seed -profile demo
+//	go run ./cmd/seed -file ./fixtures/my-school.yaml
+//	go run ./cmd/seed -profile e2e -students 500 -seed 7
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+
+	"elimu-go/internal/config"
+	"elimu-go/internal/seed"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func main() {
+	profile := flag.String("profile", "", "built-in fixture profile ("+strings.Join(seed.Profiles(), ", ")+")")
+	file := flag.String("file", "", "fixture file (.yaml, .yml or .json)")
+	students := flag.Int("students", -1, "number of synthetic students to generate (overrides the fixture)")
+	staff := flag.Int("staff", -1, "number of synthetic staff to generate (overrides the fixture)")
+	rngSeed := flag.Uint64("seed", 0, "seed for synthetic users (overrides the fixture)")
+	flag.Parse()
+
+	if (*profile == "") == (*file == "") {
+		log.Fatal("Exactly one of -profile or -file is required")
+	}
+
+	cfg, err := config.Load("")
+	if err != nil {
+		log.Fatal(err)
+	}
+	if cfg.DatabaseURL == "" {
+		log.Fatal("DB_CONNECTION not set")
+	}
+
+	var fixture *seed.Fixture
+	if *profile != "" {
+		fixture, err = seed.LoadProfile(*profile)
+	} else {
+		fixture, err = seed.LoadFile(*file)
+	}
+	if err != nil {
+		log.Fatal("Failed to load fixture: ", err)
+	}
+
+	overrides := false
+	flag.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "students", "staff", "seed":
+			overrides = true
+		}
+	})
+	if overrides {
+		if fixture.Generate == nil {
+			fixture.Generate = &seed.Generate{}
+		}
+		if *students >= 0 {
+			fixture.Generate.Students = *students
+		}
+		if *staff >= 0 {
+			fixture.Generate.Staff = *staff
+		}
+		if *rngSeed != 0 {
+			fixture.Generate.Seed = *rngSeed
+		}
+	}
+
+	ctx := context.Background()
+
+	conn, err := pgx.Connect(ctx, cfg.DatabaseURL.Value())
+	if err != nil {
+		log.Fatal("Failed to connect to DB: ", err)
+	}
+	defer conn.Close(ctx)
+
+	var res seed.Result
+	err = pgx.BeginFunc(ctx, conn, func(tx pgx.Tx) error {
+		res, err = seed.Apply(ctx, tx, fixture)
+		return err
+	})
+	if err != nil {
+		log.Fatal("Failed to seed database: ", err)
+	}
+
+	log.Printf("Seeded %d students, %d staff, %d courses, %d terms, %d offerings and %d enrollments",
+		res.Students, res.Staff, res.Courses, res.Terms, res.Offerings, res.Enrollments)
+}
@@ -0,0 +1,69 @@
+package database
+
+import (
+	"io/fs"
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations_Embedded(t *testing.T) {
+	sub, err := fs.Sub(migrationFiles, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	migrations, err := LoadMigrations(sub)
+	if err != nil {
+		t.Fatalf("Embedded migrations should load: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("Expected embedded migrations")
+	}
+
+	for i := 1; i < len(migrations); i++ {
+		if migrations[i].Version <= migrations[i-1].Version {
+			t.Errorf("Migrations out of order at %d_%s", migrations[i].Version, migrations[i].Name)
+		}
+	}
+}
+
+func TestLoadMigrations_Ordering(t *testing.T) {
+	fsys := fstest.MapFS{
+		"0010_b.up.sql":   {Data: []byte("up b")},
+		"0010_b.down.sql": {Data: []byte("down b")},
+		"0002_a.up.sql":   {Data: []byte("up a")},
+		"0002_a.down.sql": {Data: []byte("down a")},
+	}
+
+	migrations, err := LoadMigrations(fsys)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(migrations) != 2 || migrations[0].Version != 2 || migrations[1].Version != 10 {
+		t.Fatalf("Expected versions [2 10], got %+v", migrations)
+	}
+	if migrations[0].Up != "up a" || migrations[0].Down != "down a" {
+		t.Errorf("Migration bodies not loaded: %+v", migrations[0])
+	}
+}
+
+func TestLoadMigrations_Invalid(t *testing.T) {
+	cases := map[string]fstest.MapFS{
+		"missing down": {
+			"0001_a.up.sql": {Data: []byte("up")},
+		},
+		"bad name": {
+			"init.sql": {Data: []byte("up")},
+		},
+		"conflicting names": {
+			"0001_a.up.sql":   {Data: []byte("up")},
+			"0001_b.down.sql": {Data: []byte("down")},
+		},
+	}
+
+	for name, fsys := range cases {
+		if _, err := LoadMigrations(fsys); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS staff;
+DROP TABLE IF EXISTS students;
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS role_requests;
+DROP TABLE IF EXISTS role_grants;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS role_grants (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(100) NOT NULL REFERENCES staff(email) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL,
+    previous_role VARCHAR(50),
+    granted_by VARCHAR(100) NOT NULL,
+    approved_by VARCHAR(100),
+    expires_at TIMESTAMPTZ,
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS role_requests (
+    id SERIAL PRIMARY KEY,
+    email VARCHAR(100) NOT NULL REFERENCES staff(email) ON DELETE CASCADE,
+    role VARCHAR(50) NOT NULL,
+    grant_expires_at TIMESTAMPTZ,
+    reason TEXT NOT NULL DEFAULT '',
+    requested_by VARCHAR(100) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    decided_by VARCHAR(100),
+    decided_at TIMESTAMPTZ,
+    expires_at TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS role_grants_active_idx
+    ON role_grants (email) WHERE revoked_at IS NULL;
@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS courses (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    credits SMALLINT NOT NULL CHECK (credits BETWEEN 0 AND 60),
+    department VARCHAR(100) NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS courses_department_idx ON courses (department);
+
+-- Owners may edit a course; anyone else needs a course administrator role.
+CREATE TABLE IF NOT EXISTS course_owners (
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    staff_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    PRIMARY KEY (course_id, staff_id)
+);
+
+CREATE INDEX IF NOT EXISTS course_owners_staff_idx ON course_owners (staff_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS academic_records;
+DROP TABLE IF EXISTS course_requisites;
+DROP TABLE IF EXISTS course_requisite_groups;
@@ -0,0 +1,30 @@
+-- A course's requirements are the conjunction of its groups; a group is
+-- met when any one of its options is.
+CREATE TABLE IF NOT EXISTS course_requisite_groups (
+    id SERIAL PRIMARY KEY,
+    course_id INTEGER NOT NULL REFERENCES courses(id) ON DELETE CASCADE,
+    kind VARCHAR(12) NOT NULL CHECK (kind IN ('prerequisite', 'corequisite'))
+);
+
+CREATE INDEX IF NOT EXISTS course_requisite_groups_course_idx ON course_requisite_groups (course_id);
+
+-- Required courses cannot be deleted while other courses depend on them.
+CREATE TABLE IF NOT EXISTS course_requisites (
+    group_id INTEGER NOT NULL REFERENCES course_requisite_groups(id) ON DELETE CASCADE,
+    required_course_id INTEGER NOT NULL REFERENCES courses(id),
+    min_grade VARCHAR(2),
+    PRIMARY KEY (group_id, required_course_id)
+);
+
+CREATE INDEX IF NOT EXISTS course_requisites_required_idx ON course_requisites (required_course_id);
+
+-- A student's course history. A NULL grade means the course is in progress.
+CREATE TABLE IF NOT EXISTS academic_records (
+    id SERIAL PRIMARY KEY,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id),
+    grade VARCHAR(2),
+    recorded_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS academic_records_student_idx ON academic_records (student_id);
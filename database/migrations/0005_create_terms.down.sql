@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS offering_meetings;
+DROP TABLE IF EXISTS offering_instructors;
+DROP TABLE IF EXISTS course_offerings;
+DROP TABLE IF EXISTS terms;
@@ -0,0 +1,49 @@
+CREATE TABLE IF NOT EXISTS terms (
+    id SERIAL PRIMARY KEY,
+    code VARCHAR(20) UNIQUE NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    starts_on DATE NOT NULL,
+    ends_on DATE NOT NULL,
+    enrollment_opens_at TIMESTAMPTZ NOT NULL,
+    enrollment_closes_at TIMESTAMPTZ NOT NULL,
+    add_drop_deadline TIMESTAMPTZ NOT NULL,
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    CHECK (ends_on > starts_on),
+    CHECK (enrollment_closes_at > enrollment_opens_at)
+);
+
+-- Courses with offerings cannot be deleted; terms take their offerings
+-- with them.
+CREATE TABLE IF NOT EXISTS course_offerings (
+    id SERIAL PRIMARY KEY,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    course_id INTEGER NOT NULL REFERENCES courses(id),
+    section VARCHAR(10) NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity >= 0),
+    created_at TIMESTAMPTZ DEFAULT NOW(),
+    updated_at TIMESTAMPTZ DEFAULT NOW(),
+    UNIQUE (term_id, course_id, section)
+);
+
+CREATE INDEX IF NOT EXISTS course_offerings_course_idx ON course_offerings (course_id);
+
+CREATE TABLE IF NOT EXISTS offering_instructors (
+    offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+    staff_id INTEGER NOT NULL REFERENCES staff(id) ON DELETE CASCADE,
+    PRIMARY KEY (offering_id, staff_id)
+);
+
+CREATE INDEX IF NOT EXISTS offering_instructors_staff_idx ON offering_instructors (staff_id);
+
+-- Weekly meetings. weekday follows ISO 8601: 1 is Monday, 7 is Sunday.
+CREATE TABLE IF NOT EXISTS offering_meetings (
+    id SERIAL PRIMARY KEY,
+    offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 1 AND 7),
+    starts_at TIME NOT NULL,
+    ends_at TIME NOT NULL,
+    room VARCHAR(50) NOT NULL DEFAULT '',
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS offering_meetings_offering_idx ON offering_meetings (offering_id);
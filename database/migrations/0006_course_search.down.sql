@@ -0,0 +1,3 @@
+DROP VIEW IF EXISTS offering_seats;
+DROP INDEX IF EXISTS courses_search_idx;
+ALTER TABLE courses DROP COLUMN IF EXISTS search;
@@ -0,0 +1,16 @@
+-- Codes and titles rank above descriptions, which rank above departments.
+ALTER TABLE courses ADD COLUMN IF NOT EXISTS search tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english'::regconfig, code), 'A') ||
+        setweight(to_tsvector('english'::regconfig, title), 'A') ||
+        setweight(to_tsvector('english'::regconfig, description), 'B') ||
+        setweight(to_tsvector('english'::regconfig, department), 'C')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS courses_search_idx ON courses USING GIN (search);
+
+-- Seats still open in each offering. Every seat is open until enrollment
+-- exists.
+CREATE OR REPLACE VIEW offering_seats AS
+    SELECT id AS offering_id, capacity AS seats_open
+    FROM course_offerings;
@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS notifications;
+
+CREATE OR REPLACE VIEW offering_seats AS
+    SELECT id AS offering_id, capacity AS seats_open
+    FROM course_offerings;
+
+DROP TABLE IF EXISTS enrollments;
@@ -0,0 +1,37 @@
+-- Dropped enrollments are kept as history; a student holds at most one
+-- live enrollment per offering.
+CREATE TABLE IF NOT EXISTS enrollments (
+    id SERIAL PRIMARY KEY,
+    offering_id INTEGER NOT NULL REFERENCES course_offerings(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    status VARCHAR(12) NOT NULL CHECK (status IN ('enrolled', 'waitlisted', 'dropped')),
+    requested_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    enrolled_at TIMESTAMPTZ,
+    dropped_at TIMESTAMPTZ
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS enrollments_live_idx
+    ON enrollments (offering_id, student_id) WHERE status <> 'dropped';
+CREATE INDEX IF NOT EXISTS enrollments_waitlist_idx
+    ON enrollments (offering_id, requested_at, id) WHERE status = 'waitlisted';
+CREATE INDEX IF NOT EXISTS enrollments_student_idx ON enrollments (student_id);
+
+CREATE OR REPLACE VIEW offering_seats AS
+    SELECT o.id AS offering_id,
+        GREATEST(o.capacity - COUNT(e.id), 0)::integer AS seats_open
+    FROM course_offerings o
+    LEFT JOIN enrollments e ON e.offering_id = o.id AND e.status = 'enrolled'
+    GROUP BY o.id;
+
+-- In-app notifications, addressed by email so staff can receive them too.
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    recipient VARCHAR(100) NOT NULL,
+    kind VARCHAR(50) NOT NULL,
+    message TEXT NOT NULL,
+    offering_id INTEGER REFERENCES course_offerings(id) ON DELETE SET NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    read_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS notifications_recipient_idx ON notifications (recipient, created_at DESC);
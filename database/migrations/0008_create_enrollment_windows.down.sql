@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS enrollment_overrides;
+DROP TABLE IF EXISTS enrollment_windows;
+ALTER TABLE students DROP COLUMN IF EXISTS cohort;
@@ -0,0 +1,30 @@
+-- Cohorts are priority groups, e.g. a graduation year. Students without one
+-- register in the term's own enrollment window.
+ALTER TABLE students ADD COLUMN IF NOT EXISTS cohort VARCHAR(20);
+
+CREATE TABLE IF NOT EXISTS enrollment_windows (
+    id SERIAL PRIMARY KEY,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    cohort VARCHAR(20) NOT NULL,
+    opens_at TIMESTAMPTZ NOT NULL,
+    closes_at TIMESTAMPTZ NOT NULL,
+    CHECK (closes_at > opens_at),
+    UNIQUE (term_id, cohort)
+);
+
+-- Overrides let a student enroll outside their window. Revoked overrides
+-- are kept as an audit trail.
+CREATE TABLE IF NOT EXISTS enrollment_overrides (
+    id SERIAL PRIMARY KEY,
+    term_id INTEGER NOT NULL REFERENCES terms(id) ON DELETE CASCADE,
+    student_id INTEGER NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL CHECK (reason <> ''),
+    granted_by VARCHAR(100) NOT NULL,
+    expires_at TIMESTAMPTZ,
+    revoked_by VARCHAR(100),
+    revoked_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS enrollment_overrides_live_idx
+    ON enrollment_overrides (term_id, student_id) WHERE revoked_at IS NULL;
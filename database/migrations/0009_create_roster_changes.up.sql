@@ -0,0 +1,20 @@
+-- Audit trail of staff adding students to, or removing them from, an
+-- offering's roster by hand. Each change keeps its own copy of the student
+-- and offering it describes so that it outlives them.
+CREATE TABLE IF NOT EXISTS roster_changes (
+    id SERIAL PRIMARY KEY,
+    term_id INTEGER NOT NULL REFERENCES terms(id),
+    offering_id INTEGER REFERENCES course_offerings(id) ON DELETE SET NULL,
+    enrollment_id INTEGER REFERENCES enrollments(id) ON DELETE SET NULL,
+    student_email VARCHAR(100) NOT NULL,
+    term_code VARCHAR(20) NOT NULL,
+    course_code VARCHAR(20) NOT NULL,
+    section VARCHAR(10) NOT NULL,
+    action VARCHAR(10) NOT NULL CHECK (action IN ('added', 'removed')),
+    actor VARCHAR(100) NOT NULL,
+    reason TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS roster_changes_offering_idx ON roster_changes (offering_id, created_at);
+CREATE INDEX IF NOT EXISTS roster_changes_term_idx ON roster_changes (term_id, course_code, created_at);
@@ -42,78 +42,175 @@ const docTemplate = `{
                 }
             }
         },
-        "/callback": {
+        "/admin/overview": {
             "get": {
-                "description": "Processes Google OAuth callback, verifies state, exchanges code for token, and creates user session",
-                "consumes": [
+                "description": "Lists active sessions and registered users",
+                "produces": [
                     "application/json"
                 ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Admin overview",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Database unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin/roles/grants": {
+            "get": {
+                "description": "Lists role grants, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "General"
                 ],
-                "summary": "Handle OAuth callback",
+                "summary": "List role grants",
                 "parameters": [
                     {
                         "type": "string",
-                        "example": "\"4/0AX4XfWgYw...\"",
-                        "description": "Authorization code from Google",
-                        "name": "code",
-                        "in": "query",
-                        "required": true
+                        "description": "Only grants for this staff email",
+                        "name": "email",
+                        "in": "query"
                     },
                     {
-                        "type": "string",
-                        "example": "\"abc123xyz\"",
-                        "description": "State parameter for CSRF protection",
-                        "name": "state",
-                        "in": "query",
-                        "required": true
+                        "type": "boolean",
+                        "description": "Only grants that have not been revoked",
+                        "name": "active",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/handlers.LoginResponse"
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.RoleGrant"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Grants a role to a staff member. Privileged roles (admin, cto) create a pending request that a second admin must approve.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Grant a role",
+                "parameters": [
+                    {
+                        "description": "Role grant",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GrantRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Role granted",
+                        "schema": {
+                            "$ref": "#/definitions/repository.RoleGrant"
+                        }
+                    },
+                    "202": {
+                        "description": "Approval required",
+                        "schema": {
+                            "$ref": "#/definitions/repository.RoleRequest"
                         }
                     },
                     "400": {
-                        "description": "Missing or invalid authorization code",
+                        "description": "Invalid request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
-                        "description": "Invalid OAuth state parameter",
+                        "description": "Not logged in or session expired",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
-                    "500": {
-                        "description": "Google API error or server error",
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Staff member not found",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/debug": {
-            "get": {
-                "description": "Returns detailed request debug information",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/roles/grants/{id}": {
+            "delete": {
+                "description": "Ends an active grant and restores the role the staff member held before it",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "General"
                 ],
-                "summary": "Debug information",
+                "summary": "Revoke a role grant",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Grant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -121,116 +218,255 @@ const docTemplate = `{
                             "type": "object",
                             "additionalProperties": true
                         }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Grant not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
                     }
                 }
             }
         },
-        "/health": {
+        "/admin/roles/requests": {
             "get": {
-                "description": "Check if API is running properly",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists privileged role requests, newest first",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "General"
                 ],
-                "summary": "Health check",
+                "summary": "List role requests",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "pending, approved, rejected or expired",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.RoleRequest"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/login": {
-            "get": {
-                "description": "Redirects to Google OAuth with secure state parameter for authentication",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/roles/requests/{id}/approve": {
+            "post": {
+                "description": "Approves a pending privileged role request. The approver must be neither the requester nor the staff member being elevated.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "General"
+                ],
+                "summary": "Approve a role request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Start Google OAuth login",
                 "responses": {
-                    "307": {
-                        "description": "Redirect to Google"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.RoleGrant"
+                        }
                     },
-                    "500": {
-                        "description": "Server configuration error",
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions, or the requester or subject approving the request",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Request already decided or expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/logout": {
-            "get": {
-                "description": "Clears user session and logs them out",
-                "consumes": [
-                    "application/json"
-                ],
+        "/admin/roles/requests/{id}/reject": {
+            "post": {
+                "description": "Rejects a pending privileged role request",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication"
+                    "General"
+                ],
+                "summary": "Reject a role request",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Request ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Logout user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/repository.RoleRequest"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions, or the requester or subject rejecting the request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Request not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Request already decided or expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/me": {
+        "/admin/users/export": {
             "get": {
-                "description": "Returns information about currently logged in user",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Streams registered students and staff as CSV or NDJSON",
                 "produces": [
-                    "application/json"
+                    "text/csv",
+                    "application/x-ndjson"
                 ],
                 "tags": [
-                    "Authentication"
+                    "General"
+                ],
+                "summary": "Export users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Output format (csv or ndjson)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma separated columns: type, first_name, last_name, email, role, created_at",
+                        "name": "columns",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export student or staff",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only export users with this role",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 lower bound on created_at",
+                        "name": "created_after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "RFC3339 upper bound on created_at",
+                        "name": "created_before",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get current user",
                 "responses": {
                     "200": {
-                        "description": "User data",
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Invalid export parameters",
                         "schema": {
-                            "$ref": "#/definitions/handlers.User"
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     },
                     "401": {
                         "description": "Not logged in or session expired",
                         "schema": {
-                            "$ref": "#/definitions/handlers.ErrorResponse"
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
                         }
                     }
                 }
             }
         },
-        "/random": {
+        "/callback": {
             "get": {
-                "description": "Returns a random educational fact",
+                "description": "Processes Google OAuth callback, verifies state, exchanges code for token, and creates user session",
                 "consumes": [
                     "application/json"
                 ],
@@ -238,70 +474,3629 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "General"
+                    "Authentication"
+                ],
+                "summary": "Handle OAuth callback",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "example": "\"4/0AX4XfWgYw...\"",
+                        "description": "Authorization code from Google",
+                        "name": "code",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "example": "\"abc123xyz\"",
+                        "description": "State parameter for CSRF protection",
+                        "name": "state",
+                        "in": "query",
+                        "required": true
+                    }
                 ],
-                "summary": "Random student fact",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Login successful",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": true
+                            "$ref": "#/definitions/handlers.LoginResponse"
                         }
-                    }
+                    },
+                    "400": {
+                        "description": "Missing authorization code or invalid OAuth state",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "User not registered in Elimu",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "500": {
+                        "description": "Google API error or server error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "503": {
+                        "description": "Database or Google unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses": {
+            "get": {
+                "description": "Lists the course catalog ordered by code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "List courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only courses of this department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of courses to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Page-repository_Course"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid paging parameters",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a course to the catalog, owned by the caller and any listed staff",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Create a course",
+                "parameters": [
+                    {
+                        "description": "Course",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CourseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Course"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Owner is not a staff member",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Course code already in use",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/search": {
+            "get": {
+                "description": "Full-text search over course codes, titles, descriptions and departments, best match first. Term, instructor and open-seat filters must all hold for the same offering. Facets count every matching course, not just the returned page.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Search courses",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Keywords in web search syntax (quotes, OR, -word)",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only courses of this department",
+                        "name": "department",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only courses worth this many credits",
+                        "name": "credits",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only courses offered in this term",
+                        "name": "term",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only courses taught by this staff email",
+                        "name": "instructor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only courses with an offering that has a free seat",
+                        "name": "open",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page size (1-100, default 20)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of courses to skip",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CourseSearchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filters",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get a course",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Course"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces a course's details. Only its owners and course administrators (registrar, admin, cto) may change it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Update a course",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Course",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CourseRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Course"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found, or owner is not a staff member",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Course code already in use",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes a course from the catalog. Only its owners and course administrators may delete it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Delete a course",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Course is still referenced",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/eligibility": {
+            "get": {
+                "description": "Checks a student's academic record against a course's requisites. Students may only check themselves; staff may name any student.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Check course eligibility",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Student email (staff only, defaults to the caller)",
+                        "name": "student",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EligibilityResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Students may only check themselves",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or student not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/grades": {
+            "post": {
+                "description": "Adds a graded attempt of the course to a student's academic record, which eligibility checks read. Only the course's owners and course administrators may record grades.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Record a grade",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Grade",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.GradeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.AcademicRecord"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or student not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/courses/{id}/requisites": {
+            "get": {
+                "description": "Lists the prerequisite and co-requisite groups of a course. Every group must be met; a group is met by any one of its options.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Get course requisites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Requisites"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces every requisite group of a course. Changes that would make a course its own prerequisite, directly or through other courses, are rejected.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Courses"
+                ],
+                "summary": "Replace course requisites",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Course ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Requisites",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RequisitesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Requisites"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions or not an owner",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Course or required course not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "The requisites would form a cycle",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/debug": {
+            "get": {
+                "description": "Returns detailed request debug information. Only served when INTERNAL_ROUTES is public, or to admins when it is admin.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Debug information",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/health": {
+            "get": {
+                "description": "Runs every dependency check. Returns 503 when a critical check fails, including while the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "All critical checks pass",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "A critical check failed",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/live": {
+            "get": {
+                "description": "Reports that the process is running. Does not check dependencies.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Liveness probe",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/health/ready": {
+            "get": {
+                "description": "Runs every dependency check. Returns 503 when a critical check fails, including while the server is shutting down.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Readiness probe",
+                "responses": {
+                    "200": {
+                        "description": "All critical checks pass",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    },
+                    "503": {
+                        "description": "A critical check failed",
+                        "schema": {
+                            "$ref": "#/definitions/health.Report"
+                        }
+                    }
+                }
+            }
+        },
+        "/login": {
+            "get": {
+                "description": "Redirects to Google OAuth with secure state parameter for authentication",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Start Google OAuth login",
+                "responses": {
+                    "307": {
+                        "description": "Redirect to Google"
+                    },
+                    "500": {
+                        "description": "Server configuration error",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/logout": {
+            "get": {
+                "description": "Clears user session and logs them out",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/me": {
+            "get": {
+                "description": "Returns information about currently logged in user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication"
+                ],
+                "summary": "Get current user",
+                "responses": {
+                    "200": {
+                        "description": "User data",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/enrollments": {
+            "get": {
+                "description": "Lists the calling student's enrollments and waitlist places",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollment"
+                ],
+                "summary": "List my enrollments",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Only this term",
+                        "name": "term",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Enrollment"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid term",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications": {
+            "get": {
+                "description": "Lists the caller's notifications, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollment"
+                ],
+                "summary": "List my notifications",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "description": "Only unread notifications",
+                        "name": "unread",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Notification"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/notifications/{id}/read": {
+            "post": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollment"
+                ],
+                "summary": "Mark a notification read",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Notification ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Notification not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/me/timetable": {
+            "get": {
+                "description": "Lists the weekly meetings of the offerings the caller is enrolled or waitlisted in, or teaches, grouped by weekday",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollment"
+                ],
+                "summary": "Get my weekly timetable",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID; defaults to the term in progress",
+                        "name": "term",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/handlers.TimetableDay"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid term",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/offerings/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Get an offering",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Offering"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces an offering's section, capacity and meetings, and its instructors when listed",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Update an offering",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Offering",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.OfferingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Offering"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering or instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Section already exists in the term, or a room is already booked",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Removes an offering. Offerings with enrolled or waitlisted students cannot be deleted; remove them from the roster first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Delete an offering",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Students are still enrolled or waitlisted",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/offerings/{id}/enrollment": {
+            "post": {
+                "description": "Takes a seat in a course offering for the calling student. When the offering is full the student joins its waitlist if asked to, and is promoted automatically, oldest request first, when a seat frees up.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollment"
+                ],
+                "summary": "Enroll in an offering",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Options",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EnrollRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Enrolled or waitlisted",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Only students may enroll, or enrollment is not open to the student",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering or student not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already enrolled, the offering is full, or it clashes with the student's timetable",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Ends the calling student's enrollment or waitlist place. A freed seat goes to the next student on the waitlist, who is notified. Enrolled students cannot drop after the term's add/drop deadline; waitlist places can always be given up.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Enrollment"
+                ],
+                "summary": "Drop an offering",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The dropped enrollment",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Enrollment"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Only students may drop, or the add/drop deadline has passed",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering or enrollment not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/offerings/{id}/roster": {
+            "get": {
+                "description": "Lists the students enrolled in an offering by name and its waitlist in order. Only the offering's instructors and course administrators may see it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Get an offering's roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.Roster"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an instructor of this offering",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Enrolls a student by hand, bypassing enrollment windows and capacity. A waitlisted student is moved into the offering. The change is recorded with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Add a student to a roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Student",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RosterAddRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an instructor of this offering",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering or student not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Already enrolled",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/offerings/{id}/roster/changes": {
+            "get": {
+                "description": "Lists who added or removed students from the offering by hand, and why, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "List manual roster changes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.RosterChange"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an instructor of this offering",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/offerings/{id}/roster/export": {
+            "get": {
+                "description": "Downloads the roster as CSV, waitlist included, or as a printable PDF sign-in sheet of the enrolled students",
+                "produces": [
+                    "text/csv",
+                    "application/pdf"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Export an offering's roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "csv",
+                        "description": "Output format (csv or pdf)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK"
+                    },
+                    "400": {
+                        "description": "Invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an instructor of this offering",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/offerings/{id}/roster/{enrollment}": {
+            "delete": {
+                "description": "Drops an enrollment or waitlist place by hand. A freed seat goes to the next student on the waitlist. The change is recorded with the reason.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "Remove a student from a roster",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Offering ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Enrollment ID",
+                        "name": "enrollment",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Reason",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/handlers.RosterRemoveRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "The dropped enrollment",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Enrollment"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Not an instructor of this offering",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Offering or enrollment not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/random": {
+            "get": {
+                "description": "Returns a random educational fact",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "General"
+                ],
+                "summary": "Random student fact",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": true
+                        }
+                    }
+                }
+            }
+        },
+        "/students/cohort": {
+            "put": {
+                "description": "Sets the cohort whose enrollment window the student uses. An empty cohort makes the student use each term's own window.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Assign a student's cohort",
+                "parameters": [
+                    {
+                        "description": "Cohort",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.StudentCohortRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.StudentCohort"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms": {
+            "get": {
+                "description": "Lists every term, latest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "List terms",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Term"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Create a term",
+                "parameters": [
+                    {
+                        "description": "Term",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Term"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Term code already in use",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}": {
+            "get": {
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Get a term",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Term"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Update a term",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Term",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.TermRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Term"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Term code already in use",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/offerings": {
+            "get": {
+                "description": "Lists the course sections scheduled in a term, ordered by course code and section",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "List a term's offerings",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.Offering"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a section of a course to a term, with its instructors and weekly meetings",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Schedule a course",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Offering",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CreateOfferingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.Offering"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term, course or instructor not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "409": {
+                        "description": "Section already exists in the term, or a room is already booked",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/offerings/clone": {
+            "post": {
+                "description": "Copies every offering of a previous term, with instructors and meetings, into this one. Sections that already exist here are skipped. Offerings needing a room that is already booked at the same time here are left out and listed in clashes.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Clone offerings from another term",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Target term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source term",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.CloneOfferingsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.CloneResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/overrides": {
+            "get": {
+                "description": "Lists the term's late-registration overrides, revoked ones included, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "List enrollment overrides",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.EnrollmentOverride"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Lets a student enroll in the term outside their enrollment window. The reason and the granting admin are recorded; a new override replaces the student's previous one.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Grant an enrollment override",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Override",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EnrollmentOverrideRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/repository.EnrollmentOverride"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term or student not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/overrides/{override}": {
+            "delete": {
+                "description": "Ends an override. It stays listed with who revoked it.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Revoke an enrollment override",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Override ID",
+                        "name": "override",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/repository.EnrollmentOverride"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Override not found or already revoked",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/roster-changes": {
+            "get": {
+                "description": "Lists who added or removed students by hand in any offering of the term, deleted offerings included, newest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Roster"
+                ],
+                "summary": "List a term's manual roster changes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only changes to this course code",
+                        "name": "course",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.RosterChange"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        },
+        "/terms/{id}/windows": {
+            "get": {
+                "description": "Lists the per-cohort enrollment windows of a term. Students whose cohort has no window enroll during the term's own window.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "List a term's enrollment windows",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.EnrollmentWindow"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Replaces the per-cohort enrollment windows of a term, e.g. to let seniors register first",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Terms"
+                ],
+                "summary": "Set a term's enrollment windows",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Term ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Windows",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/handlers.EnrollmentWindowsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/repository.EnrollmentWindow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "401": {
+                        "description": "Not logged in or session expired",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "403": {
+                        "description": "Insufficient permissions",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    },
+                    "404": {
+                        "description": "Term not found",
+                        "schema": {
+                            "$ref": "#/definitions/problem.Problem"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handlers.CloneOfferingsRequest": {
+            "type": "object",
+            "required": [
+                "from_term_id"
+            ],
+            "properties": {
+                "from_term_id": {
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.CourseRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "credits",
+                "department",
+                "title"
+            ],
+            "properties": {
+                "code": {
+                    "description": "Catalog code, e.g. CS101. Stored upper-case.",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "credits": {
+                    "type": "integer",
+                    "maximum": 60,
+                    "minimum": 0
+                },
+                "department": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "description": {
+                    "type": "string"
+                },
+                "owners": {
+                    "description": "Staff emails owning the course. On create the caller is always an\nowner; on update an absent list keeps the current owners and an\nempty one is rejected.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string",
+                    "maxLength": 200
+                }
+            }
+        },
+        "handlers.CourseSearchResponse": {
+            "type": "object",
+            "properties": {
+                "facets": {
+                    "$ref": "#/definitions/repository.CourseFacets"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.Course"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.CreateOfferingRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "course_id",
+                "section"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "course_id": {
+                    "type": "integer",
+                    "minimum": 1
+                },
+                "instructors": {
+                    "description": "Staff emails teaching the offering. On update an absent list keeps\nthe current instructors.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meetings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MeetingRequest"
+                    }
+                },
+                "section": {
+                    "type": "string",
+                    "maxLength": 10
+                }
+            }
+        },
+        "handlers.EligibilityResponse": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "eligible": {
+                    "type": "boolean"
+                },
+                "missing": {
+                    "description": "Requisite groups the student's record does not meet",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.RequisiteGroup"
+                    }
+                },
+                "student": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.EnrollRequest": {
+            "type": "object",
+            "properties": {
+                "waitlist": {
+                    "description": "Join the waitlist if the offering is full",
+                    "type": "boolean"
+                }
+            }
+        },
+        "handlers.EnrollmentOverrideRequest": {
+            "type": "object",
+            "required": [
+                "reason",
+                "student"
+            ],
+            "properties": {
+                "expires_at": {
+                    "description": "Optional end of the override; it lasts until revoked otherwise",
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "student": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.EnrollmentWindowRequest": {
+            "type": "object",
+            "required": [
+                "closes_at",
+                "cohort",
+                "opens_at"
+            ],
+            "properties": {
+                "closes_at": {
+                    "type": "string"
+                },
+                "cohort": {
+                    "description": "Cohort or priority group, e.g. a graduation year",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "opens_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.EnrollmentWindowsRequest": {
+            "type": "object",
+            "properties": {
+                "windows": {
+                    "description": "An empty list removes every cohort window, leaving the term's own",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.EnrollmentWindowRequest"
+                    }
+                }
+            }
+        },
+        "handlers.GradeRequest": {
+            "type": "object",
+            "required": [
+                "grade",
+                "student"
+            ],
+            "properties": {
+                "grade": {
+                    "description": "Letter grade, e.g. B+",
+                    "type": "string"
+                },
+                "student": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.GrantRoleRequest": {
+            "type": "object",
+            "required": [
+                "email",
+                "role"
+            ],
+            "properties": {
+                "email": {
+                    "description": "Staff email to grant the role to",
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "Optional time after which the grant reverts",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the role is needed",
+                    "type": "string"
+                },
+                "role": {
+                    "description": "Role to grant",
+                    "type": "string",
+                    "maxLength": 50
+                }
+            }
+        },
+        "handlers.LoginResponse": {
+            "type": "object",
+            "properties": {
+                "message": {
+                    "description": "Success message\nexample: Login successful!",
+                    "type": "string"
+                },
+                "user": {
+                    "description": "Authenticated user data",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/models.User"
+                        }
+                    ]
+                }
+            }
+        },
+        "handlers.MeetingRequest": {
+            "type": "object",
+            "required": [
+                "ends_at",
+                "starts_at",
+                "weekday"
+            ],
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "room": {
+                    "type": "string",
+                    "maxLength": 50
+                },
+                "starts_at": {
+                    "description": "Wall-clock times, HH:MM",
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "ISO weekday: 1 is Monday, 7 is Sunday",
+                    "type": "integer",
+                    "maximum": 7,
+                    "minimum": 1
+                }
+            }
+        },
+        "handlers.OfferingRequest": {
+            "type": "object",
+            "required": [
+                "capacity",
+                "section"
+            ],
+            "properties": {
+                "capacity": {
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "instructors": {
+                    "description": "Staff emails teaching the offering. On update an absent list keeps\nthe current instructors.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "meetings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.MeetingRequest"
+                    }
+                },
+                "section": {
+                    "type": "string",
+                    "maxLength": 10
+                }
+            }
+        },
+        "handlers.Page-repository_Course": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.Course"
+                    }
+                },
+                "limit": {
+                    "type": "integer"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.RequirementRequest": {
+            "type": "object",
+            "required": [
+                "code"
+            ],
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "min_grade": {
+                    "description": "Lowest acceptable letter grade, e.g. C+. Empty accepts any pass.",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RequisiteGroupRequest": {
+            "type": "object",
+            "required": [
+                "kind",
+                "options"
+            ],
+            "properties": {
+                "kind": {
+                    "description": "prerequisite or corequisite",
+                    "type": "string",
+                    "enum": [
+                        "prerequisite",
+                        "corequisite"
+                    ]
+                },
+                "options": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/handlers.RequirementRequest"
+                    }
+                }
+            }
+        },
+        "handlers.RequisitesRequest": {
+            "type": "object",
+            "required": [
+                "groups"
+            ],
+            "properties": {
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.RequisiteGroupRequest"
+                    }
+                }
+            }
+        },
+        "handlers.Roster": {
+            "type": "object",
+            "properties": {
+                "enrolled": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.RosterEntry"
+                    }
+                },
+                "offering": {
+                    "$ref": "#/definitions/repository.Offering"
+                },
+                "waitlisted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.RosterEntry"
+                    }
+                }
+            }
+        },
+        "handlers.RosterAddRequest": {
+            "type": "object",
+            "required": [
+                "student"
+            ],
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                },
+                "student": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.RosterRemoveRequest": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string",
+                    "maxLength": 500
+                }
+            }
+        },
+        "handlers.StudentCohortRequest": {
+            "type": "object",
+            "required": [
+                "student"
+            ],
+            "properties": {
+                "cohort": {
+                    "description": "Cohort or priority group, e.g. a graduation year; empty removes the\nstudent from their cohort",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "student": {
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.TermRequest": {
+            "type": "object",
+            "required": [
+                "add_drop_deadline",
+                "code",
+                "ends_on",
+                "enrollment_closes_at",
+                "enrollment_opens_at",
+                "name",
+                "starts_on"
+            ],
+            "properties": {
+                "add_drop_deadline": {
+                    "type": "string"
+                },
+                "code": {
+                    "description": "Short unique code, e.g. 2026-S1",
+                    "type": "string",
+                    "maxLength": 20
+                },
+                "ends_on": {
+                    "type": "string"
+                },
+                "enrollment_closes_at": {
+                    "type": "string"
+                },
+                "enrollment_opens_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "starts_on": {
+                    "description": "First and last day of teaching, YYYY-MM-DD",
+                    "type": "string"
+                }
+            }
+        },
+        "handlers.TimetableDay": {
+            "type": "object",
+            "properties": {
+                "day": {
+                    "type": "string"
+                },
+                "meetings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.TimetableEntry"
+                    }
+                },
+                "weekday": {
+                    "description": "ISO weekday: 1 is Monday, 7 is Sunday",
+                    "type": "integer"
+                }
+            }
+        },
+        "handlers.User": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "google_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "picture": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "health.Report": {
+            "type": "object",
+            "properties": {
+                "checks": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/health.Result"
+                    }
+                },
+                "status": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "health.Result": {
+            "type": "object",
+            "properties": {
+                "critical": {
+                    "type": "boolean"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "latency_ms": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "models.User": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "google_id": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "picture": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "problem.FieldError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "description": "Field name as the client sent it\nexample: expires_at",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "What is wrong with it\nexample: must be in the future",
+                    "type": "string"
+                }
+            }
+        },
+        "problem.Problem": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Stable machine-readable error code\nexample: auth.not_registered",
+                    "type": "string"
+                },
+                "conflict": {
+                    "description": "What the request clashed with, such as the meeting already holding\na room"
+                },
+                "detail": {
+                    "description": "Explanation specific to this occurrence",
+                    "type": "string"
+                },
+                "errors": {
+                    "description": "Fields that failed validation",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/problem.FieldError"
+                    }
+                },
+                "instance": {
+                    "description": "Path of the request that failed\nexample: /api/callback",
+                    "type": "string"
+                },
+                "request_id": {
+                    "description": "ID of the request, as sent in X-Request-ID",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "HTTP status code\nexample: 403",
+                    "type": "integer"
+                },
+                "title": {
+                    "description": "Short summary that does not change between occurrences\nexample: User not registered in Elimu",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "URI identifying the kind of problem\nexample: urn:elimu:problem:auth.not_registered",
+                    "type": "string"
+                }
+            }
+        },
+        "repository.AcademicRecord": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "grade": {
+                    "description": "Grade is nil while the course is in progress.",
+                    "type": "string"
+                },
+                "recorded_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.CloneClash": {
+            "type": "object",
+            "properties": {
+                "course_code": {
+                    "type": "string"
+                },
+                "meeting": {
+                    "$ref": "#/definitions/repository.Meeting"
+                },
+                "offering_id": {
+                    "type": "integer"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "with": {
+                    "$ref": "#/definitions/repository.ScheduledMeeting"
+                }
+            }
+        },
+        "repository.CloneResult": {
+            "type": "object",
+            "properties": {
+                "clashes": {
+                    "description": "Clashes lists offerings left out because a room they meet in is\nalready booked at the same time in the target term.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.CloneClash"
+                    }
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "description": "Skipped counts offerings whose course and section already existed in\nthe target term.",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.Course": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "credits": {
+                    "type": "integer"
+                },
+                "department": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "owners": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.CourseOwner"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.CourseFacets": {
+            "type": "object",
+            "properties": {
+                "credits": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.FacetCount"
+                    }
+                },
+                "departments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.FacetCount"
+                    }
+                },
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.FacetCount"
+                    }
+                },
+                "open_seats": {
+                    "description": "OpenSeats counts courses with at least one offering that has a free\nseat.",
+                    "type": "integer"
+                },
+                "terms": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.FacetCount"
+                    }
+                }
+            }
+        },
+        "repository.CourseOwner": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.Enrollment": {
+            "type": "object",
+            "properties": {
+                "course_code": {
+                    "type": "string"
+                },
+                "dropped_at": {
+                    "type": "string"
+                },
+                "enrolled_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "offering_id": {
+                    "type": "integer"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_email": {
+                    "type": "string"
+                },
+                "term_id": {
+                    "type": "integer"
+                },
+                "waitlist_position": {
+                    "description": "WaitlistPosition counts from 1 and is only set while waitlisted.",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.EnrollmentOverride": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "description": "ExpiresAt is nil for an override that lasts until revoked.",
+                    "type": "string"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "revoked_by": {
+                    "type": "string"
+                },
+                "student_email": {
+                    "type": "string"
+                },
+                "term_id": {
+                    "type": "integer"
                 }
             }
-        }
-    },
-    "definitions": {
-        "handlers.ErrorResponse": {
+        },
+        "repository.EnrollmentWindow": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "Error message\nexample: Invalid authorization code",
+                "closes_at": {
+                    "type": "string"
+                },
+                "cohort": {
+                    "type": "string"
+                },
+                "opens_at": {
                     "type": "string"
                 }
             }
         },
-        "handlers.LoginResponse": {
+        "repository.FacetCount": {
+            "type": "object",
+            "properties": {
+                "count": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.Instructor": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "staff_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.Meeting": {
             "type": "object",
             "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "room": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "ISO weekday: 1 is Monday, 7 is Sunday.",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "kind": {
+                    "type": "string"
+                },
                 "message": {
-                    "description": "Success message\nexample: Login successful!",
                     "type": "string"
                 },
-                "user": {
-                    "description": "Authenticated user data",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/handlers.User"
-                        }
-                    ]
+                "offering_id": {
+                    "type": "integer"
+                },
+                "read_at": {
+                    "type": "string"
                 }
             }
         },
-        "handlers.User": {
+        "repository.Offering": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "course_code": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "course_title": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "instructors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.Instructor"
+                    }
+                },
+                "meetings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.Meeting"
+                    }
+                },
+                "section": {
+                    "type": "string"
+                },
+                "term_id": {
+                    "type": "integer"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.Requirement": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "course_id": {
+                    "type": "integer"
+                },
+                "min_grade": {
+                    "description": "MinGrade is the lowest acceptable grade. Empty accepts any pass.",
+                    "type": "string"
+                }
+            }
+        },
+        "repository.RequisiteGroup": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "options": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.Requirement"
+                    }
+                }
+            }
+        },
+        "repository.Requisites": {
+            "type": "object",
+            "properties": {
+                "course_id": {
+                    "type": "integer"
+                },
+                "groups": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/repository.RequisiteGroup"
+                    }
+                }
+            }
+        },
+        "repository.RoleGrant": {
             "type": "object",
             "properties": {
+                "approved_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
                 "email": {
-                    "description": "User's email address\nexample: elvischege@student.school.edu",
                     "type": "string"
                 },
-                "google_id": {
-                    "description": "Google's unique identifier\nexample: 12345678901234567890",
+                "expires_at": {
+                    "type": "string"
+                },
+                "granted_by": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "previous_role": {
+                    "type": "string"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.RoleRequest": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "string"
                 },
+                "decided_at": {
+                    "type": "string"
+                },
+                "decided_by": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "grant_expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "requested_by": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.RosterChange": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "course_code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "enrollment_id": {
+                    "type": "integer"
+                },
                 "id": {
-                    "description": "User's unique ID\nexample: 12345",
+                    "type": "integer"
+                },
+                "offering_id": {
+                    "type": "integer"
+                },
+                "reason": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "student_email": {
+                    "type": "string"
+                },
+                "term_code": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.RosterEntry": {
+            "type": "object",
+            "properties": {
+                "cohort": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "enrolled_at": {
+                    "type": "string"
+                },
+                "enrollment_id": {
+                    "type": "integer"
+                },
+                "first_name": {
+                    "type": "string"
+                },
+                "last_name": {
+                    "type": "string"
+                },
+                "requested_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "waitlist_position": {
+                    "description": "WaitlistPosition counts from 1 and is only set while waitlisted.",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.ScheduledMeeting": {
+            "type": "object",
+            "properties": {
+                "course_code": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "offering_id": {
+                    "type": "integer"
+                },
+                "room": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "ISO weekday: 1 is Monday, 7 is Sunday.",
+                    "type": "integer"
+                }
+            }
+        },
+        "repository.StudentCohort": {
+            "type": "object",
+            "properties": {
+                "cohort": {
+                    "description": "Cohort is nil for a student who uses the term's own window.",
+                    "type": "string"
+                },
+                "student_email": {
+                    "type": "string"
+                }
+            }
+        },
+        "repository.Term": {
+            "type": "object",
+            "properties": {
+                "add_drop_deadline": {
+                    "description": "AddDropDeadline is the last moment students may drop without record.",
+                    "type": "string"
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "ends_on": {
+                    "type": "string"
+                },
+                "enrollment_closes_at": {
+                    "type": "string"
+                },
+                "enrollment_opens_at": {
                     "type": "string"
                 },
+                "id": {
+                    "type": "integer"
+                },
                 "name": {
-                    "description": "User's full name\nexample: Elvis Chege",
                     "type": "string"
                 },
-                "picture": {
-                    "description": "URL to user's profile picture\nexample: https://lh3.googleusercontent.com/a/...",
+                "starts_on": {
+                    "description": "First and last day of teaching.",
+                    "type": "string"
+                }
+            }
+        },
+        "repository.TimetableEntry": {
+            "type": "object",
+            "properties": {
+                "course_code": {
                     "type": "string"
+                },
+                "course_title": {
+                    "type": "string"
+                },
+                "ends_at": {
+                    "type": "string"
+                },
+                "offering_id": {
+                    "type": "integer"
+                },
+                "role": {
+                    "description": "Role is \"student\" for offerings taken and \"instructor\" for those\ntaught.",
+                    "type": "string"
+                },
+                "room": {
+                    "type": "string"
+                },
+                "section": {
+                    "type": "string"
+                },
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Status is the enrollment status for offerings taken.",
+                    "type": "string"
+                },
+                "weekday": {
+                    "description": "ISO weekday: 1 is Monday, 7 is Sunday.",
+                    "type": "integer"
                 }
             }
         }
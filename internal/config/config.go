@@ -0,0 +1,367 @@
+// Package config loads the API configuration.
+//
+// Values are resolved in increasing order of precedence: built-in defaults,
+// an optional YAML file, a .env file in the working directory, and finally
+// the process environment.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"elimu-go/internal/logging"
+
+	"github.com/joho/godotenv"
+	"go.yaml.in/yaml/v3"
+)
+
+// Environments the API knows how to run in.
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvProduction  = "production"
+)
+
+// Secret is a string that never prints its value.
+type Secret string
+
+const redacted = "[redacted]"
+
+func (s Secret) String() string {
+	if s == "" {
+		return ""
+	}
+	return redacted
+}
+
+func (s Secret) GoString() string { return strconv.Quote(s.String()) }
+
+func (s Secret) MarshalJSON() ([]byte, error) { return []byte(strconv.Quote(s.String())), nil }
+
+// Value returns the secret itself.
+func (s Secret) Value() string { return string(s) }
+
+// Google holds the OAuth client settings.
+type Google struct {
+	ClientID     string `yaml:"client_id"`
+	ClientSecret Secret `yaml:"client_secret"`
+	RedirectURL  string `yaml:"redirect_url"`
+}
+
+// Shutdown controls how the server stops.
+type Shutdown struct {
+	// Delay is how long the server keeps serving after readiness starts
+	// failing, so load balancers stop routing to it first.
+	Delay time.Duration `yaml:"delay"`
+
+	// Timeout bounds how long in-flight requests may take to drain.
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// Trace exporters.
+const (
+	TraceExporterNone   = "none"
+	TraceExporterOTLP   = "otlp"
+	TraceExporterStdout = "stdout"
+)
+
+// Tracing controls OpenTelemetry tracing. The OTLP exporter reads its
+// endpoint and headers from the standard OTEL_EXPORTER_OTLP_* variables.
+type Tracing struct {
+	Exporter    string  `yaml:"exporter"`
+	SampleRatio float64 `yaml:"sample_ratio"`
+}
+
+// How the debug endpoint and swagger UI are exposed.
+const (
+	InternalRoutesPublic = "public"
+	InternalRoutesAdmin  = "admin"
+	InternalRoutesOff    = "off"
+)
+
+// CORS lists the browser origins allowed to call the API.
+type CORS struct {
+	// AllowedOrigins are full origins such as https://app.elimu.ac.ke, or
+	// "*" for any origin, which cannot be combined with credentials.
+	AllowedOrigins   []string `yaml:"allowed_origins"`
+	AllowCredentials bool     `yaml:"allow_credentials"`
+	AllowedMethods   []string `yaml:"allowed_methods"`
+}
+
+// devOrigins are the SPA dev servers allowed in development when no
+// origins are configured.
+var devOrigins = []string{"http://localhost:3000", "http://localhost:5173"}
+
+// Config is the typed API configuration.
+type Config struct {
+	Env         string   `yaml:"env"`
+	Port        string   `yaml:"port"`
+	LogLevel    string   `yaml:"log_level"`
+	DatabaseURL Secret   `yaml:"database_url"`
+	AutoMigrate bool     `yaml:"auto_migrate"`
+	Google      Google   `yaml:"google"`
+	Shutdown    Shutdown `yaml:"shutdown"`
+	Tracing     Tracing  `yaml:"tracing"`
+	CORS        CORS     `yaml:"cors"`
+
+	// TrustedProxies are the IPs or CIDRs of load balancers whose
+	// X-Forwarded-For header is believed. Empty trusts none.
+	TrustedProxies []string `yaml:"trusted_proxies"`
+
+	// InternalRoutes exposes /api/debug and /swagger publicly, to admins
+	// only, or not at all. It defaults to off in production.
+	InternalRoutes string `yaml:"internal_routes"`
+}
+
+// ValidationError lists every problem found in a configuration.
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration:\n  - " + strings.Join(e.Problems, "\n  - ")
+}
+
+// Default returns the configuration used when nothing is set.
+func Default() Config {
+	return Config{
+		Env:      EnvDevelopment,
+		Port:     "8080",
+		LogLevel: "info",
+		Google: Google{
+			RedirectURL: "http://localhost:8080/api/callback",
+		},
+		Shutdown: Shutdown{
+			Delay:   5 * time.Second,
+			Timeout: 30 * time.Second,
+		},
+		Tracing: Tracing{
+			Exporter:    TraceExporterNone,
+			SampleRatio: 1,
+		},
+		CORS: CORS{
+			AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE"},
+		},
+	}
+}
+
+// Load resolves the configuration. path names an optional YAML file; when
+// empty, CONFIG_FILE is consulted.
+func Load(path string) (*Config, error) {
+	// .env never overrides variables that are already set, which gives the
+	// process environment precedence over it.
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("load .env: %w", err)
+	}
+
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	return load(path, os.LookupEnv)
+}
+
+func load(path string, lookup func(string) (string, bool)) (*Config, error) {
+	cfg := Default()
+
+	if path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read config file: %w", err)
+		}
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&cfg); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+
+	var problems []string
+	str := func(dst *string, name string) {
+		if v, ok := lookup(name); ok {
+			*dst = v
+		}
+	}
+	secret := func(dst *Secret, name string) {
+		if v, ok := lookup(name); ok {
+			*dst = Secret(v)
+		}
+	}
+	duration := func(dst *time.Duration, name string) {
+		if v, ok := lookup(name); ok && v != "" {
+			d, err := time.ParseDuration(v)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a duration", name, v))
+				return
+			}
+			*dst = d
+		}
+	}
+	list := func(dst *[]string, name string) {
+		if v, ok := lookup(name); ok {
+			*dst = nil
+			for _, item := range strings.Split(v, ",") {
+				if item = strings.TrimSpace(item); item != "" {
+					*dst = append(*dst, item)
+				}
+			}
+		}
+	}
+	float := func(dst *float64, name string) {
+		if v, ok := lookup(name); ok && v != "" {
+			f, err := strconv.ParseFloat(v, 64)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a number", name, v))
+				return
+			}
+			*dst = f
+		}
+	}
+	boolean := func(dst *bool, name string) {
+		if v, ok := lookup(name); ok && v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				problems = append(problems, fmt.Sprintf("%s: %q is not a boolean", name, v))
+				return
+			}
+			*dst = b
+		}
+	}
+
+	str(&cfg.Env, "APP_ENV")
+	str(&cfg.Port, "PORT")
+	str(&cfg.LogLevel, "LOG_LEVEL")
+	secret(&cfg.DatabaseURL, "DB_CONNECTION")
+	boolean(&cfg.AutoMigrate, "AUTO_MIGRATE")
+	str(&cfg.Google.ClientID, "GOOGLE_CLIENT_ID")
+	secret(&cfg.Google.ClientSecret, "GOOGLE_CLIENT_SECRET")
+	str(&cfg.Google.RedirectURL, "GOOGLE_REDIRECT_URL")
+	duration(&cfg.Shutdown.Delay, "SHUTDOWN_DELAY")
+	duration(&cfg.Shutdown.Timeout, "SHUTDOWN_TIMEOUT")
+	str(&cfg.Tracing.Exporter, "TRACING_EXPORTER")
+	float(&cfg.Tracing.SampleRatio, "TRACING_SAMPLE_RATIO")
+	list(&cfg.CORS.AllowedOrigins, "CORS_ALLOWED_ORIGINS")
+	boolean(&cfg.CORS.AllowCredentials, "CORS_ALLOW_CREDENTIALS")
+	list(&cfg.CORS.AllowedMethods, "CORS_ALLOWED_METHODS")
+	list(&cfg.TrustedProxies, "TRUSTED_PROXIES")
+	str(&cfg.InternalRoutes, "INTERNAL_ROUTES")
+
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
+
+	// Local SPA dev servers work out of the box; other environments must
+	// list their origins.
+	if cfg.Env == EnvDevelopment && cfg.CORS.AllowedOrigins == nil {
+		cfg.CORS.AllowedOrigins = devOrigins
+		cfg.CORS.AllowCredentials = true
+	}
+	if cfg.InternalRoutes == "" {
+		cfg.InternalRoutes = InternalRoutesPublic
+		if cfg.IsProduction() {
+			cfg.InternalRoutes = InternalRoutesOff
+		}
+	}
+	return &cfg, nil
+}
+
+// Validate checks everything the API server needs and reports all problems
+// at once rather than failing on the first.
+func (c *Config) Validate() error {
+	var problems []string
+
+	switch c.Env {
+	case EnvDevelopment, EnvTest, EnvProduction:
+	default:
+		problems = append(problems, fmt.Sprintf("APP_ENV: must be %s, %s or %s, got %q",
+			EnvDevelopment, EnvTest, EnvProduction, c.Env))
+	}
+
+	if port, err := strconv.Atoi(c.Port); err != nil || port < 1 || port > 65535 {
+		problems = append(problems, fmt.Sprintf("PORT: %q is not a valid port", c.Port))
+	}
+
+	if _, err := logging.ParseLevel(c.LogLevel); err != nil {
+		problems = append(problems, "LOG_LEVEL: must be debug, info, warn or error")
+	}
+
+	if c.DatabaseURL == "" {
+		problems = append(problems, "DB_CONNECTION: required")
+	}
+
+	if c.Google.ClientID == "" {
+		problems = append(problems, "GOOGLE_CLIENT_ID: required")
+	}
+	if c.Google.ClientSecret == "" {
+		problems = append(problems, "GOOGLE_CLIENT_SECRET: required")
+	}
+	if u, err := url.Parse(c.Google.RedirectURL); err != nil || u.Scheme == "" || u.Host == "" {
+		problems = append(problems, fmt.Sprintf("GOOGLE_REDIRECT_URL: %q is not an absolute URL", c.Google.RedirectURL))
+	}
+
+	if c.Shutdown.Delay < 0 {
+		problems = append(problems, "SHUTDOWN_DELAY: must not be negative")
+	}
+	if c.Shutdown.Timeout <= 0 {
+		problems = append(problems, "SHUTDOWN_TIMEOUT: must be positive")
+	}
+
+	switch c.Tracing.Exporter {
+	case TraceExporterNone, TraceExporterOTLP, TraceExporterStdout:
+	default:
+		problems = append(problems, fmt.Sprintf("TRACING_EXPORTER: must be %s, %s or %s, got %q",
+			TraceExporterNone, TraceExporterOTLP, TraceExporterStdout, c.Tracing.Exporter))
+	}
+	if c.Tracing.SampleRatio < 0 || c.Tracing.SampleRatio > 1 {
+		problems = append(problems, "TRACING_SAMPLE_RATIO: must be between 0 and 1")
+	}
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				problems = append(problems, `CORS_ALLOWED_ORIGINS: "*" cannot be combined with CORS_ALLOW_CREDENTIALS`)
+			}
+			continue
+		}
+		if u, err := url.Parse(origin); err != nil || u.Scheme == "" || u.Host == "" || u.Path != "" {
+			problems = append(problems, fmt.Sprintf("CORS_ALLOWED_ORIGINS: %q is not an origin like https://app.example.com", origin))
+		}
+	}
+
+	for _, proxy := range c.TrustedProxies {
+		if net.ParseIP(proxy) == nil {
+			if _, _, err := net.ParseCIDR(proxy); err != nil {
+				problems = append(problems, fmt.Sprintf("TRUSTED_PROXIES: %q is not an IP or CIDR", proxy))
+			}
+		}
+	}
+
+	switch c.InternalRoutes {
+	case InternalRoutesAdmin, InternalRoutesOff:
+	case InternalRoutesPublic:
+		if c.IsProduction() {
+			problems = append(problems, "INTERNAL_ROUTES: must be admin or off in production")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("INTERNAL_ROUTES: must be %s, %s or %s, got %q",
+			InternalRoutesPublic, InternalRoutesAdmin, InternalRoutesOff, c.InternalRoutes))
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// IsProduction reports whether the API runs in production.
+func (c *Config) IsProduction() bool {
+	return c.Env == EnvProduction
+}
@@ -0,0 +1,187 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func env(vars map[string]string) func(string) (string, bool) {
+	return func(name string) (string, bool) {
+		v, ok := vars[name]
+		return v, ok
+	}
+}
+
+func TestLoad_Precedence(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte(`
+port: "9000"
+database_url: postgres://file
+google:
+  client_id: file-client
+`), 0o600)
+
+	cfg, err := load(path, env(map[string]string{
+		"PORT":             "9100",
+		"GOOGLE_CLIENT_ID": "env-client",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if cfg.Port != "9100" {
+		t.Errorf("Environment should override the file, got port %s", cfg.Port)
+	}
+	if cfg.DatabaseURL.Value() != "postgres://file" {
+		t.Errorf("File should override defaults, got %q", cfg.DatabaseURL.Value())
+	}
+	if cfg.Google.ClientID != "env-client" {
+		t.Errorf("Expected env-client, got %s", cfg.Google.ClientID)
+	}
+	if cfg.Google.RedirectURL != Default().Google.RedirectURL {
+		t.Errorf("Unset values should keep their default, got %s", cfg.Google.RedirectURL)
+	}
+}
+
+func TestLoad_RejectsUnknownKeys(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	os.WriteFile(path, []byte("prot: 9000\n"), 0o600)
+
+	if _, err := load(path, env(nil)); err == nil {
+		t.Error("Misspelled keys should be rejected")
+	}
+}
+
+func TestValidate_ReportsEveryProblem(t *testing.T) {
+	cfg, err := load("", env(map[string]string{"APP_ENV": "staging", "PORT": "http", "LOG_LEVEL": "loud", "TRACING_EXPORTER": "jaeger"}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	err = cfg.Validate()
+	var verr *ValidationError
+	if !errors.As(err, &verr) {
+		t.Fatalf("Expected a ValidationError, got %v", err)
+	}
+
+	for _, want := range []string{"APP_ENV", "PORT", "LOG_LEVEL", "TRACING_EXPORTER", "DB_CONNECTION", "GOOGLE_CLIENT_ID", "GOOGLE_CLIENT_SECRET"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected a problem about %s in:\n%v", want, err)
+		}
+	}
+}
+
+func TestValidate_OK(t *testing.T) {
+	cfg, _ := load("", env(map[string]string{
+		"DB_CONNECTION":        "postgres://localhost/elimu",
+		"GOOGLE_CLIENT_ID":     "id",
+		"GOOGLE_CLIENT_SECRET": "secret",
+	}))
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("Expected a valid config, got %v", err)
+	}
+}
+
+func TestSecretsAreRedacted(t *testing.T) {
+	cfg := Default()
+	cfg.DatabaseURL = "postgres://user:hunter2@db/elimu"
+	cfg.Google.ClientSecret = "hunter2"
+
+	data, _ := json.Marshal(cfg)
+	for _, out := range []string{
+		fmt.Sprintf("%v", cfg),
+		fmt.Sprintf("%+v", cfg),
+		fmt.Sprintf("%#v", cfg),
+		string(data),
+	} {
+		if strings.Contains(out, "hunter2") {
+			t.Errorf("Secret leaked in %s", out)
+		}
+	}
+}
+
+func TestLoad_InvalidEnv(t *testing.T) {
+	_, err := load("", env(map[string]string{
+		"AUTO_MIGRATE":     "sometimes",
+		"SHUTDOWN_TIMEOUT": "30",
+	}))
+
+	var verr *ValidationError
+	if !errors.As(err, &verr) || len(verr.Problems) != 2 {
+		t.Errorf("Expected two problems, got %v", err)
+	}
+}
+
+func TestLoad_CORS(t *testing.T) {
+	cfg, err := load("", env(nil))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.CORS.AllowedOrigins) == 0 || !cfg.CORS.AllowCredentials {
+		t.Errorf("Expected local dev servers to be allowed in development, got %+v", cfg.CORS)
+	}
+
+	cfg, err = load("", env(map[string]string{
+		"APP_ENV":                "production",
+		"CORS_ALLOWED_ORIGINS":   "https://app.elimu.test, https://admin.elimu.test",
+		"CORS_ALLOW_CREDENTIALS": "true",
+	}))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cfg.CORS.AllowedOrigins) != 2 || cfg.CORS.AllowedOrigins[1] != "https://admin.elimu.test" {
+		t.Errorf("Expected both origins, got %v", cfg.CORS.AllowedOrigins)
+	}
+}
+
+func TestValidate_CORS(t *testing.T) {
+	cfg, _ := load("", env(map[string]string{
+		"APP_ENV":                "production",
+		"CORS_ALLOWED_ORIGINS":   "*,app.elimu.test,https://app.elimu.test/path",
+		"CORS_ALLOW_CREDENTIALS": "true",
+	}))
+
+	var verr *ValidationError
+	if !errors.As(cfg.Validate(), &verr) {
+		t.Fatal("Expected a ValidationError")
+	}
+
+	var cors int
+	for _, p := range verr.Problems {
+		if strings.HasPrefix(p, "CORS_ALLOWED_ORIGINS") {
+			cors++
+		}
+	}
+	if cors != 3 {
+		t.Errorf("Expected 3 CORS problems, got %d in:\n%v", cors, verr)
+	}
+}
+
+func TestLoad_InternalRoutes(t *testing.T) {
+	dev, _ := load("", env(nil))
+	if dev.InternalRoutes != InternalRoutesPublic {
+		t.Errorf("Expected public internal routes in development, got %q", dev.InternalRoutes)
+	}
+
+	prod, _ := load("", env(map[string]string{"APP_ENV": "production"}))
+	if prod.InternalRoutes != InternalRoutesOff {
+		t.Errorf("Expected internal routes off in production, got %q", prod.InternalRoutes)
+	}
+
+	prod, _ = load("", env(map[string]string{
+		"APP_ENV":         "production",
+		"INTERNAL_ROUTES": "public",
+		"TRUSTED_PROXIES": "10.0.0.0/8,lb.internal",
+	}))
+	err := prod.Validate()
+	for _, want := range []string{"INTERNAL_ROUTES", `TRUSTED_PROXIES: "lb.internal"`} {
+		if err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected a problem about %s, got %v", want, err)
+		}
+	}
+}
@@ -3,34 +3,49 @@ package handlers
 import (
 	"net/http"
 
+	"elimu-go/internal/repository"
+
 	"github.com/gin-gonic/gin"
 )
 
+// AdminHandler serves the /admin endpoints.
+type AdminHandler struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	roles    repository.RoleRepository
+}
+
+func NewAdminHandler(users repository.UserRepository, sessions repository.SessionRepository, roles repository.RoleRepository) *AdminHandler {
+	return &AdminHandler{users: users, sessions: sessions, roles: roles}
+}
+
 // AdminOverview godoc
 // @Summary      Admin overview
 // @Description  Lists active sessions and registered users
 // @Tags         General
 // @Produce      json
 // @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Failure      503  {object}  problem.Problem  "Database unavailable"
 // @Router       /admin/overview [get]
-func AdminOverview(c *gin.Context) {
-	students, err := getAllStudents()
+func (h *AdminHandler) AdminOverview(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	students, err := h.users.ListStudents(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "Failed to load students",
-		})
+		writeError(c, err, "Failed to load students")
 		return
 	}
 
-	staff, err := getAllStaff()
+	staff, err := h.users.ListStaff(ctx)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{
-			Error: "Failed to load staff",
-		})
+		writeError(c, err, "Failed to load staff")
 		return
 	}
 
-	active := getActiveSessions()
+	active := h.sessions.List()
 
 	c.JSON(http.StatusOK, gin.H{
 		"active_sessions": active,
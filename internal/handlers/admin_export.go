@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// exportFlushEvery controls how many rows are written before the response
+// is flushed to the client.
+const exportFlushEvery = 500
+
+// exportColumns lists the columns that can be selected for a user export,
+// in their default order.
+var exportColumns = []string{"type", "first_name", "last_name", "email", "role", "created_at"}
+
+func exportValue(r repository.UserExportRow, column string) any {
+	switch column {
+	case "type":
+		return r.Type
+	case "first_name":
+		return r.FirstName
+	case "last_name":
+		return r.LastName
+	case "email":
+		return r.Email
+	case "role":
+		return r.Role
+	case "created_at":
+		if r.CreatedAt == nil {
+			return nil
+		}
+		return r.CreatedAt.UTC().Format(time.RFC3339)
+	}
+	return nil
+}
+
+// userExporter writes export rows in a specific format.
+type userExporter interface {
+	WriteRow(repository.UserExportRow) error
+	Flush() error
+}
+
+type csvExporter struct {
+	w       *csv.Writer
+	columns []string
+}
+
+func newCSVExporter(w io.Writer, columns []string) (*csvExporter, error) {
+	e := &csvExporter{w: csv.NewWriter(w), columns: columns}
+	if err := e.w.Write(columns); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *csvExporter) WriteRow(row repository.UserExportRow) error {
+	record := make([]string, len(e.columns))
+	for i, col := range e.columns {
+		if v := exportValue(row, col); v != nil {
+			record[i] = fmt.Sprint(v)
+		}
+	}
+	return e.w.Write(record)
+}
+
+func (e *csvExporter) Flush() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+type ndjsonExporter struct {
+	enc     *json.Encoder
+	columns []string
+}
+
+func newNDJSONExporter(w io.Writer, columns []string) *ndjsonExporter {
+	return &ndjsonExporter{enc: json.NewEncoder(w), columns: columns}
+}
+
+func (e *ndjsonExporter) WriteRow(row repository.UserExportRow) error {
+	record := make(map[string]any, len(e.columns))
+	for _, col := range e.columns {
+		record[col] = exportValue(row, col)
+	}
+	return e.enc.Encode(record)
+}
+
+func (e *ndjsonExporter) Flush() error { return nil }
+
+func parseExportColumns(raw string) ([]string, error) {
+	if raw == "" {
+		return exportColumns, nil
+	}
+
+	allowed := make(map[string]struct{}, len(exportColumns))
+	for _, col := range exportColumns {
+		allowed[col] = struct{}{}
+	}
+
+	var columns []string
+	seen := make(map[string]struct{})
+	for _, col := range strings.Split(raw, ",") {
+		col = strings.TrimSpace(col)
+		if _, ok := allowed[col]; !ok {
+			return nil, problem.FieldError{Field: "columns", Message: fmt.Sprintf("has unknown column %q", col)}
+		}
+		if _, dup := seen[col]; dup {
+			continue
+		}
+		seen[col] = struct{}{}
+		columns = append(columns, col)
+	}
+	return columns, nil
+}
+
+func parseExportFilter(c *gin.Context) (repository.UserExportFilter, error) {
+	f := repository.UserExportFilter{
+		Type: c.Query("type"),
+		Role: c.Query("role"),
+	}
+
+	switch f.Type {
+	case "", "student", "staff":
+	default:
+		return f, problem.FieldError{Field: "type", Message: "must be student or staff"}
+	}
+
+	for name, dst := range map[string]**time.Time{
+		"created_after":  &f.CreatedAfter,
+		"created_before": &f.CreatedBefore,
+	} {
+		raw := c.Query(name)
+		if raw == "" {
+			continue
+		}
+		t, err := time.Parse(time.RFC3339, raw)
+		if err != nil {
+			return f, problem.FieldError{Field: name, Message: "must be an RFC3339 timestamp"}
+		}
+		*dst = &t
+	}
+
+	return f, nil
+}
+
+// ExportUsers godoc
+// @Summary      Export users
+// @Description  Streams registered students and staff as CSV or NDJSON
+// @Tags         General
+// @Produce      text/csv
+// @Produce      application/x-ndjson
+// @Param        format          query  string  false  "Output format (csv or ndjson)"  default(csv)
+// @Param        columns         query  string  false  "Comma separated columns: type, first_name, last_name, email, role, created_at"
+// @Param        type            query  string  false  "Only export student or staff"
+// @Param        role            query  string  false  "Only export users with this role"
+// @Param        created_after   query  string  false  "RFC3339 lower bound on created_at"
+// @Param        created_before  query  string  false  "RFC3339 upper bound on created_at"
+// @Success      200
+// @Failure      400  {object}  problem.Problem  "Invalid export parameters"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Router       /admin/users/export [get]
+func (h *AdminHandler) ExportUsers(c *gin.Context) {
+	columns, err := parseExportColumns(c.Query("columns"))
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	filter, err := parseExportFilter(c)
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	var (
+		exporter    userExporter
+		contentType string
+		ext         string
+	)
+	switch format := c.DefaultQuery("format", "csv"); format {
+	case "csv":
+		contentType, ext = "text/csv; charset=utf-8", "csv"
+	case "ndjson":
+		contentType, ext = "application/x-ndjson", "ndjson"
+	default:
+		problem.Invalid(c, problem.FieldError{Field: "format", Message: "must be csv or ndjson"})
+		return
+	}
+
+	c.Header("Content-Type", contentType)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="users.%s"`, ext))
+	c.Status(http.StatusOK)
+
+	if ext == "csv" {
+		exporter, err = newCSVExporter(c.Writer, columns)
+		if err != nil {
+			slog.ErrorContext(c.Request.Context(), "export: failed to write header", "error", err)
+			return
+		}
+	} else {
+		exporter = newNDJSONExporter(c.Writer, columns)
+	}
+
+	n := 0
+	err = h.users.StreamUsers(c.Request.Context(), filter, func(row repository.UserExportRow) error {
+		if err := exporter.WriteRow(row); err != nil {
+			return err
+		}
+		n++
+		if n%exportFlushEvery == 0 {
+			if err := exporter.Flush(); err != nil {
+				return err
+			}
+			c.Writer.Flush()
+		}
+		return nil
+	})
+	if err != nil {
+		// Headers are already sent, all we can do is stop the stream.
+		slog.ErrorContext(c.Request.Context(), "export: aborted", "rows", n, "error", err)
+		return
+	}
+
+	if err := exporter.Flush(); err != nil {
+		slog.ErrorContext(c.Request.Context(), "export: failed to flush", "error", err)
+	}
+	c.Writer.Flush()
+}
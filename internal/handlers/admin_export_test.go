@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestParseExportColumns(t *testing.T) {
+	cols, err := parseExportColumns("")
+	if err != nil || len(cols) != len(exportColumns) {
+		t.Errorf("Empty columns should select all columns, got %v (%v)", cols, err)
+	}
+
+	cols, err = parseExportColumns("email, first_name,email")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(cols) != 2 || cols[0] != "email" || cols[1] != "first_name" {
+		t.Errorf("Expected [email first_name], got %v", cols)
+	}
+
+	if _, err := parseExportColumns("email,password"); err == nil {
+		t.Error("Unknown column should be rejected")
+	}
+}
+
+func TestCSVExporter(t *testing.T) {
+	var buf bytes.Buffer
+	e, err := newCSVExporter(&buf, []string{"email", "role", "created_at"})
+	if err != nil {
+		t.Fatalf("Failed to create exporter: %v", err)
+	}
+
+	created := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+	e.WriteRow(repository.UserExportRow{Email: "a@school.edu", Role: "admin", CreatedAt: &created})
+	e.WriteRow(repository.UserExportRow{Email: "b@school.edu", Role: "student"})
+	if err := e.Flush(); err != nil {
+		t.Fatalf("Flush failed: %v", err)
+	}
+
+	want := "email,role,created_at\n" +
+		"a@school.edu,admin,2025-01-02T03:04:05Z\n" +
+		"b@school.edu,student,\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV output:\n%s", buf.String())
+	}
+}
+
+func TestNDJSONExporter(t *testing.T) {
+	var buf bytes.Buffer
+	e := newNDJSONExporter(&buf, []string{"email", "type"})
+	e.WriteRow(repository.UserExportRow{Type: "staff", Email: "a@school.edu", Role: "cto"})
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Output should be valid JSON: %v", err)
+	}
+	if len(got) != 2 || got["email"] != "a@school.edu" || got["type"] != "staff" {
+		t.Errorf("Unexpected NDJSON record: %v", got)
+	}
+}
+
+func TestExportUsers_InvalidParams(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewAdminHandler(repository.NewMemoryUserRepository(), repository.NewMemorySessionRepository(), nil)
+
+	for _, query := range []string{
+		"?format=xml",
+		"?columns=password",
+		"?type=parent",
+		"?created_after=yesterday",
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/admin/users/export"+query, nil)
+
+		h.ExportUsers(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestExportUsers_CSV(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	users := repository.NewMemoryUserRepository()
+	users.AddStudent(repository.StudentRow{FirstName: "Amani", Email: "amani@school.edu"})
+	users.AddStaff(repository.StaffRow{FirstName: "Baraka", Email: "baraka@school.edu", Role: "teacher"})
+	h := NewAdminHandler(users, repository.NewMemorySessionRepository(), nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/admin/users/export?columns=email,role&type=staff", nil)
+
+	h.ExportUsers(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if want := "email,role\nbaraka@school.edu,teacher\n"; w.Body.String() != want {
+		t.Errorf("Unexpected export body:\n%s", w.Body.String())
+	}
+}
+
+func TestExportUsers_CreatedFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	users := repository.NewMemoryUserRepository()
+	for _, email := range []string{"old@school.edu", "mid@school.edu", "new@school.edu"} {
+		users.AddStudent(repository.StudentRow{FirstName: "S", Email: email})
+	}
+	users.SetCreatedAt("old@school.edu", time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	users.SetCreatedAt("mid@school.edu", time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	users.SetCreatedAt("new@school.edu", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	h := NewAdminHandler(users, repository.NewMemorySessionRepository(), nil)
+
+	cases := map[string]string{
+		"created_after=2025-01-01T00:00:00Z":                                     "mid@school.edu\nnew@school.edu\n",
+		"created_before=2025-01-01T00:00:00Z":                                    "old@school.edu\n",
+		"created_after=2024-06-01T00:00:00Z&created_before=2025-06-01T00:00:00Z": "mid@school.edu\n",
+	}
+	for query, want := range cases {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/admin/users/export?columns=email&"+query, nil)
+
+		h.ExportUsers(c)
+
+		if got := strings.TrimPrefix(w.Body.String(), "email\n"); w.Code != http.StatusOK || got != want {
+			t.Errorf("%s: expected 200 with\n%s\ngot %d\n%s", query, want, w.Code, got)
+		}
+	}
+}
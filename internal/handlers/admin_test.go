@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"elimu-go/internal/repository"
+	"elimu-go/internal/testdb"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestAdminOverview_Postgres(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `INSERT INTO students (first_name, last_name, email) VALUES ('Amani', 'Wanjiru', 'amani@student.school.edu')`)
+	testdb.Exec(t, pool, `INSERT INTO staff (first_name, last_name, email, role) VALUES ('Ruth', 'Njeri', 'ruth@school.edu', NULL)`)
+
+	sessions := repository.NewMemorySessionRepository()
+	sessions.Save("session_1", &User{ID: "1", Email: "ruth@school.edu"})
+	h := NewAdminHandler(repository.NewPgUserRepository(pool), sessions, repository.NewPgRoleRepository(pool))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/admin/overview", nil)
+
+	h.AdminOverview(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d: %s", w.Code, w.Body.String())
+	}
+
+	var body struct {
+		ActiveSessions []User                  `json:"active_sessions"`
+		Students       []repository.StudentRow `json:"students"`
+		Staff          []repository.StaffRow   `json:"staff"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if len(body.ActiveSessions) != 1 || len(body.Students) != 1 || len(body.Staff) != 1 {
+		t.Errorf("Unexpected overview: %+v", body)
+	}
+}
+
+func TestGoogleCallback_Postgres(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `INSERT INTO students (first_name, last_name, email) VALUES ('Amani', 'Wanjiru', 'amani@school.edu')`)
+
+	h, _, sessions := newTestAuthHandler()
+	h.users = repository.NewPgUserRepository(pool)
+
+	if w := callbackRequest(h, "amani"); w.Code != http.StatusOK {
+		t.Errorf("Expected 200 for registered student, got %d", w.Code)
+	}
+	if u, ok := sessions.Get("session_g_amani"); !ok || u.Role != "student" {
+		t.Errorf("Expected student session, got %+v", u)
+	}
+
+	if w := callbackRequest(h, "stranger"); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for unregistered user, got %d", w.Code)
+	}
+}
@@ -29,27 +29,6 @@ func Welcome(c *gin.Context) {
 	})
 }
 
-// HealthCheck godoc
-// @Summary health check
-// @Description  Check if API is running properly
-// @Tags         General
-// @Accept       json
-// @Produce      json
-// @Success      200  {object}  map[string]interface{}
-// @Router       /health [get]
-// @Example      Response
-//
-//	{
-//	  "status": "Healthy",
-//	  "timestamp": 1702867200
-//	}
-func HealthCheck(c *gin.Context) {
-	c.JSON(http.StatusOK, gin.H{
-		"status":    "Healthy",
-		"timestamp": time.Now().Unix(),
-	})
-}
-
 // RandomEndpoint godoc
 // @Summary      Random student fact
 // @Description  Returns a random educational fact
@@ -76,7 +55,7 @@ func RandomEndpoint(c *gin.Context) {
 
 // DebugInfo godoc
 // @Summary      Debug information
-// @Description  Returns detailed request debug information
+// @Description  Returns detailed request debug information. Only served when INTERNAL_ROUTES is public, or to admins when it is admin.
 // @Tags         General
 // @Accept       json
 // @Produce      json
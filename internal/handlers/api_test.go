@@ -24,16 +24,3 @@ func TestWelcomeEndpoint(t *testing.T) {
 		t.Error("Welcome returned empty response")
 	}
 }
-
-func TestHealthCheck(t *testing.T) {
-	gin.SetMode(gin.TestMode)
-
-	w := httptest.NewRecorder()
-	c, _ := gin.CreateTestContext(w)
-
-	HealthCheck(c)
-
-	if w.Code != http.StatusOK {
-		t.Errorf("HealthCheck should return 200, got %d", w.Code)
-	}
-}
@@ -3,39 +3,30 @@ package handlers
 import (
 	"context"
 	"crypto/rand"
+	"elimu-go/internal/config"
+	"elimu-go/internal/logging"
+	"elimu-go/internal/metrics"
 	"elimu-go/internal/models"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+	"elimu-go/internal/tracing"
 	"encoding/base64"
 	"encoding/json"
+	"errors"
+	"fmt"
 
 	"io"
-	"log"
+	"log/slog"
 	"net/http"
-	"os"
-	"sync"
 
 	"github.com/gin-gonic/gin"
-	"github.com/joho/godotenv"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
 )
 
-var (
-	oauthConfig *oauth2.Config
-	sessions    = &sync.Map{}
-)
-
-var Sessions = sessions // expose
-
 // User represents an authenticated user
 // swagger:model User
-
-// ErrorResponse represents an API error
-// swagger:model ErrorResponse
-type ErrorResponse struct {
-	// Error message
-	// example: Invalid authorization code
-	Error string `json:"error"`
-}
+type User = models.User
 
 // LoginResponse represents successful login
 // swagger:model LoginResponse
@@ -48,59 +39,44 @@ type LoginResponse struct {
 	User *models.User `json:"user"`
 }
 
-func userExists(email string) (bool, string, error) {
-	ctx := context.Background()
-
-	var role string
-
-	// check students
-	err := DB.QueryRow(ctx,
-		`SELECT 'student' FROM students WHERE email=$1`,
-		email,
-	).Scan(&role)
-
-	if err == nil {
-		return true, role, nil
-	}
-
-	// check staff
-	err = DB.QueryRow(ctx,
-		`SELECT role FROM staff WHERE email=$1`,
-		email,
-	).Scan(&role)
-
-	if err == nil {
-		return true, role, nil
-	}
+var (
+	errTokenExchange = errors.New("Token exchange failed")
+	errUserInfo      = errors.New("Failed to get user info")
+)
 
-	return false, "", nil
+// googleProfile is the subset of Google's userinfo response we use.
+type googleProfile struct {
+	ID      string `json:"id"`
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+	Picture string `json:"picture"`
 }
 
-func getActiveSessions() []models.User {
-	var users []models.User
+// AuthHandler serves the Google OAuth login flow and session endpoints.
+type AuthHandler struct {
+	users    repository.UserRepository
+	sessions repository.SessionRepository
+	oauth    *oauth2.Config
+	metrics  *metrics.Metrics
 
-	sessions.Range(func(_, value any) bool {
-		if u, ok := value.(*models.User); ok {
-			users = append(users, *u)
-		}
-		return true
-	})
-
-	return users
+	// fetchProfile exchanges an authorization code for the user's Google
+	// profile. Tests replace it to avoid calling Google.
+	fetchProfile func(ctx context.Context, code string) (*googleProfile, error)
 }
 
-func init() {
-	godotenv.Load()
-
-	clientID := os.Getenv("GOOGLE_CLIENT_ID")
-	if clientID == "" {
-		log.Println("Client ID empty in init")
-	}
+// NewAuthHandler builds the handler. m may be nil to skip login metrics.
+func NewAuthHandler(users repository.UserRepository, sessions repository.SessionRepository, oauth *oauth2.Config, m *metrics.Metrics) *AuthHandler {
+	h := &AuthHandler{users: users, sessions: sessions, oauth: oauth, metrics: m}
+	h.fetchProfile = h.googleProfile
+	return h
+}
 
-	oauthConfig = &oauth2.Config{
-		ClientID:     clientID,
-		ClientSecret: os.Getenv("GOOGLE_CLIENT_SECRET"),
-		RedirectURL:  "http://localhost:8080/api/callback",
+// GoogleOAuthConfig builds the OAuth client for Google sign-in.
+func GoogleOAuthConfig(cfg config.Google) *oauth2.Config {
+	return &oauth2.Config{
+		ClientID:     cfg.ClientID,
+		ClientSecret: cfg.ClientSecret.Value(),
+		RedirectURL:  cfg.RedirectURL,
 		Scopes: []string{
 			"https://www.googleapis.com/auth/userinfo.email",
 			"https://www.googleapis.com/auth/userinfo.profile",
@@ -116,18 +92,18 @@ func init() {
 // @Accept       json
 // @Produce      json
 // @Success      307  "Redirect to Google"
-// @Failure      500  {object}  ErrorResponse  "Server configuration error"
+// @Failure      500  {object}  problem.Problem  "Server configuration error"
 // @Router       /login [get]
 // @Example      Request
 // GET /api/login
-func GoogleLogin(c *gin.Context) {
+func (h *AuthHandler) GoogleLogin(c *gin.Context) {
 	b := make([]byte, 32)
 	rand.Read(b)
 	state := base64.URLEncoding.EncodeToString(b)
 
 	c.SetCookie("oauth_state", state, 300, "/", "", false, true)
 
-	authURL := oauthConfig.AuthCodeURL(state)
+	authURL := h.oauth.AuthCodeURL(state)
 	c.Redirect(http.StatusTemporaryRedirect, authURL)
 }
 
@@ -140,9 +116,10 @@ func GoogleLogin(c *gin.Context) {
 // @Param        code   query  string  true  "Authorization code from Google"  example("4/0AX4XfWgYw...")
 // @Param        state  query  string  true  "State parameter for CSRF protection"  example("abc123xyz")
 // @Success      200    {object}  LoginResponse  "Login successful"
-// @Failure      400    {object}  ErrorResponse  "Missing or invalid authorization code"
-// @Failure      401    {object}  ErrorResponse  "Invalid OAuth state parameter"
-// @Failure      500    {object}  ErrorResponse  "Google API error or server error"
+// @Failure      400    {object}  problem.Problem  "Missing authorization code or invalid OAuth state"
+// @Failure      403    {object}  problem.Problem  "User not registered in Elimu"
+// @Failure      500    {object}  problem.Problem  "Google API error or server error"
+// @Failure      503    {object}  problem.Problem  "Database or Google unavailable"
 // @Router       /callback [get]
 // @Example      Response
 //
@@ -156,73 +133,75 @@ func GoogleLogin(c *gin.Context) {
 //	    "google_id": "12345678901234567890"
 //	  }
 //	}
-func GoogleCallback(c *gin.Context) {
+func (h *AuthHandler) GoogleCallback(c *gin.Context) {
 	receivedState := c.Query("state")
 	expectedState, err := c.Cookie("oauth_state")
 
 	if err != nil || receivedState != expectedState {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid state parameter"})
+		h.metrics.LoginFailed(metrics.LoginStateMismatch)
+		problem.Abort(c, problem.InvalidState, "")
 		return
 	}
 
 	code := c.Query("code")
 	if code == "" {
-		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "No code provided"})
+		h.metrics.LoginFailed(metrics.LoginMissingCode)
+		problem.Abort(c, problem.MissingCode, "")
 		return
 	}
 
-	token, err := oauthConfig.Exchange(context.Background(), code)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Token exchange failed"})
-		return
-	}
+	ctx, cancel := requestContext(c)
+	defer cancel()
 
-	client := oauthConfig.Client(context.Background(), token)
-	resp, err := client.Get("https://www.googleapis.com/oauth2/v2/userinfo")
+	profile, err := h.fetchProfile(ctx, code)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Failed to get user info"})
+		slog.WarnContext(ctx, "google login failed", "error", err)
+		switch {
+		case errors.Is(err, context.DeadlineExceeded):
+			h.metrics.LoginFailed(metrics.LoginGoogleTimeout)
+			problem.Abort(c, problem.GoogleTimeout, "")
+		case errors.Is(err, errTokenExchange):
+			h.metrics.LoginFailed(metrics.LoginTokenExchange)
+			problem.Abort(c, problem.TokenExchange, "")
+		default:
+			h.metrics.LoginFailed(metrics.LoginUserInfo)
+			problem.Abort(c, problem.UserInfo, "")
+		}
 		return
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
-
-	var userInfo struct {
-		ID      string `json:"id"`
-		Email   string `json:"email"`
-		Name    string `json:"name"`
-		Picture string `json:"picture"`
-	}
-	json.Unmarshal(body, &userInfo)
 
 	// 🔑 FIX: Check DB first to get role before creating user
-	exists, role, err := userExists(userInfo.Email)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Database error"})
+	role, err := h.users.FindRole(ctx, profile.Email)
+	if errors.Is(err, repository.ErrNotFound) {
+		h.metrics.LoginFailed(metrics.LoginNotRegistered)
+		problem.Abort(c, problem.NotRegistered, "")
 		return
 	}
-	if !exists {
-		c.JSON(http.StatusForbidden, ErrorResponse{Error: "User not registered in Elimu"})
+	if err != nil {
+		h.metrics.LoginFailed(metrics.LoginDatabaseError)
+		writeError(c, err, "Database error")
 		return
 	}
 
 	// Create user with role after DB check
 	user := &models.User{
-		ID:       userInfo.ID,
-		Email:    userInfo.Email,
-		Name:     userInfo.Name,
-		Picture:  userInfo.Picture,
-		GoogleID: userInfo.ID,
+		ID:       profile.ID,
+		Email:    profile.Email,
+		Name:     profile.Name,
+		Picture:  profile.Picture,
+		GoogleID: profile.ID,
 		Role:     role, // <-- now included
 	}
 
 	sessionID := "session_" + user.ID
-	sessions.Store(sessionID, user)
+	h.sessions.Save(sessionID, user)
 
 	c.SetCookie("session_id", sessionID, 3600, "/", "", false, true)
 	c.SetCookie("oauth_state", "", -1, "/", "", false, true)
 
-	log.Printf("User logged in: %s (%s)", user.Email, role)
+	h.metrics.LoginSucceeded()
+	logging.SetUser(c.Request.Context(), user.ID, user.Role)
+	slog.InfoContext(ctx, "user logged in", "email", user.Email)
 
 	c.JSON(http.StatusOK, LoginResponse{
 		Message: "Login successful!",
@@ -237,7 +216,7 @@ func GoogleCallback(c *gin.Context) {
 // @Accept       json
 // @Produce      json
 // @Success      200  {object}  User  "User data"
-// @Failure      401  {object}  ErrorResponse  "Not logged in or session expired"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
 // @Router       /me [get]
 // @Example      Response
 //
@@ -248,22 +227,16 @@ func GoogleCallback(c *gin.Context) {
 //	  "picture": "https://lh3.googleusercontent.com/a/...",
 //	  "google_id": "12345678901234567890"
 //	}
-func GetCurrentUser(c *gin.Context) {
+func (h *AuthHandler) GetCurrentUser(c *gin.Context) {
 	sessionID, err := c.Cookie("session_id")
 	if err != nil {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Not logged in"})
+		problem.Abort(c, problem.NotLoggedIn, "")
 		return
 	}
 
-	value, exists := sessions.Load(sessionID)
+	user, exists := h.sessions.Get(sessionID)
 	if !exists {
-		c.JSON(http.StatusUnauthorized, ErrorResponse{Error: "Session expired"})
-		return
-	}
-
-	user, ok := value.(*models.User)
-	if !ok {
-		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Invalid session user"})
+		problem.Abort(c, problem.SessionExpired, "")
 		return
 	}
 
@@ -283,11 +256,51 @@ func GetCurrentUser(c *gin.Context) {
 //	{
 //	  "message": "Logged out successfully"
 //	}
-func Logout(c *gin.Context) {
+func (h *AuthHandler) Logout(c *gin.Context) {
 	sessionID, err := c.Cookie("session_id")
 	if err == nil {
-		sessions.Delete(sessionID)
+		h.sessions.Delete(sessionID)
 	}
 	c.SetCookie("session_id", "", -1, "/", "", false, true)
 	c.JSON(http.StatusOK, gin.H{"message": "Logged out"})
 }
+
+func (h *AuthHandler) googleProfile(ctx context.Context, code string) (*googleProfile, error) {
+	// oauth2 makes both the exchange and userinfo calls with this client.
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, tracing.HTTPClient())
+
+	token, err := h.oauth.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errTokenExchange, err)
+	}
+
+	client := h.oauth.Client(ctx, token)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://www.googleapis.com/oauth2/v2/userinfo", nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUserInfo, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("%w: %s", errUserInfo, resp.Status)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errUserInfo, err)
+	}
+
+	var profile googleProfile
+	if err := json.Unmarshal(body, &profile); err != nil {
+		return nil, fmt.Errorf("%w: %w", errUserInfo, err)
+	}
+	if profile.Email == "" {
+		return nil, fmt.Errorf("%w: no email address", errUserInfo)
+	}
+
+	return &profile, nil
+}
@@ -1,26 +1,50 @@
 package handlers
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"net/http/httptest"
-	"sync"
 	"testing"
 
+	"elimu-go/internal/config"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
 	"github.com/gin-gonic/gin"
 )
 
+func newTestAuthHandler() (*AuthHandler, *repository.MemoryUserRepository, *repository.MemorySessionRepository) {
+	users := repository.NewMemoryUserRepository()
+	sessions := repository.NewMemorySessionRepository()
+	h := NewAuthHandler(users, sessions, GoogleOAuthConfig(config.Default().Google), nil)
+	h.fetchProfile = func(_ context.Context, code string) (*googleProfile, error) {
+		return &googleProfile{ID: "g_" + code, Email: code + "@school.edu", Name: code}, nil
+	}
+	return h, users, sessions
+}
+
+func callbackRequest(h *AuthHandler, code string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/callback?state=abc&code="+code, nil)
+	c.Request.AddCookie(&http.Cookie{Name: "oauth_state", Value: "abc"})
+
+	h.GoogleCallback(c)
+	return w
+}
+
 func TestGetCurrentUser_NoSession(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
-	// Reset sessions for clean test
-	sessions = &sync.Map{}
+	h, _, _ := newTestAuthHandler()
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
 	c.Request = httptest.NewRequest("GET", "/api/me", nil)
 
-	GetCurrentUser(c)
+	h.GetCurrentUser(c)
 
 	if w.Code != http.StatusUnauthorized {
 		t.Errorf("Expected 401 for no session, got %d", w.Code)
@@ -30,6 +54,8 @@ func TestGetCurrentUser_NoSession(t *testing.T) {
 func TestGetCurrentUser_ValidSession(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
+	h, _, sessions := newTestAuthHandler()
+
 	// Setup: Create a user in sessions
 	testUser := &User{
 		ID:       "test_123",
@@ -38,7 +64,7 @@ func TestGetCurrentUser_ValidSession(t *testing.T) {
 		Picture:  "https://example.com/pic.jpg",
 		GoogleID: "google_123",
 	}
-	sessions.Store("session_test_123", testUser)
+	sessions.Save("session_test_123", testUser)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -50,7 +76,7 @@ func TestGetCurrentUser_ValidSession(t *testing.T) {
 		Value: "session_test_123",
 	})
 
-	GetCurrentUser(c)
+	h.GetCurrentUser(c)
 
 	if w.Code != http.StatusOK {
 		t.Errorf("Expected 200 with valid session, got %d", w.Code)
@@ -60,9 +86,11 @@ func TestGetCurrentUser_ValidSession(t *testing.T) {
 func TestLogout_WithSession(t *testing.T) {
 	gin.SetMode(gin.TestMode)
 
+	h, _, sessions := newTestAuthHandler()
+
 	// Setup: Add a session
 	testUser := &User{ID: "logout_test"}
-	sessions.Store("session_logout_test", testUser)
+	sessions.Save("session_logout_test", testUser)
 
 	w := httptest.NewRecorder()
 	c, _ := gin.CreateTestContext(w)
@@ -74,10 +102,10 @@ func TestLogout_WithSession(t *testing.T) {
 		Value: "session_logout_test",
 	})
 
-	Logout(c)
+	h.Logout(c)
 
 	// Check session was deleted
-	_, exists := sessions.Load("session_logout_test")
+	_, exists := sessions.Get("session_logout_test")
 	if exists {
 		t.Error("Session should have been deleted after logout")
 	}
@@ -87,6 +115,72 @@ func TestLogout_WithSession(t *testing.T) {
 	}
 }
 
+func TestGoogleCallback_InvalidState(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h, _, _ := newTestAuthHandler()
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/callback?state=forged&code=x", nil)
+	c.Request.AddCookie(&http.Cookie{Name: "oauth_state", Value: "abc"})
+
+	h.GoogleCallback(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for mismatched state, got %d", w.Code)
+	}
+}
+
+func TestGoogleCallback_RegisteredStaff(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h, users, sessions := newTestAuthHandler()
+	users.AddStaff(repository.StaffRow{Email: "teacher@school.edu", Role: "admin"})
+
+	w := callbackRequest(h, "teacher")
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for registered user, got %d", w.Code)
+	}
+
+	u, ok := sessions.Get("session_g_teacher")
+	if !ok {
+		t.Fatal("Expected a session to be created")
+	}
+	if u.Role != "admin" {
+		t.Errorf("Expected session role admin, got %q", u.Role)
+	}
+}
+
+func TestGoogleCallback_NotRegistered(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h, _, sessions := newTestAuthHandler()
+
+	w := callbackRequest(h, "stranger")
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for unregistered user, got %d", w.Code)
+	}
+	if len(sessions.List()) != 0 {
+		t.Error("No session should be created for unregistered users")
+	}
+}
+
+func TestGoogleCallback_DatabaseError(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h, users, _ := newTestAuthHandler()
+	users.Err = &repository.UnavailableError{Err: errors.New("connection refused")}
+
+	w := callbackRequest(h, "student")
+
+	if w.Code != http.StatusServiceUnavailable {
+		t.Errorf("Expected 503 when the database is unreachable, got %d", w.Code)
+	}
+}
+
 func TestUserStructJSON(t *testing.T) {
 	// Test that User struct marshals correctly
 	user := &User{
@@ -107,12 +201,10 @@ func TestUserStructJSON(t *testing.T) {
 
 func TestErrorResponses(t *testing.T) {
 	// Test your error response format
-	errResp := ErrorResponse{
-		Error: "Test error",
-	}
+	errResp := problem.New(problem.NotRegistered, "Test error")
 
 	_, err := json.Marshal(errResp)
 	if err != nil {
-		t.Errorf("ErrorResponse should marshal to JSON: %v", err)
+		t.Errorf("Problem should marshal to JSON: %v", err)
 	}
 }
@@ -0,0 +1,357 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// courseAdminRoles may change any course; other staff only the courses
+// they own.
+var courseAdminRoles = []string{"registrar", "admin", "cto"}
+
+// CourseHandler serves the course catalog.
+type CourseHandler struct {
+	courses    repository.CourseRepository
+	requisites repository.RequisiteRepository
+	records    repository.RecordRepository
+}
+
+func NewCourseHandler(courses repository.CourseRepository, requisites repository.RequisiteRepository, records repository.RecordRepository) *CourseHandler {
+	return &CourseHandler{courses: courses, requisites: requisites, records: records}
+}
+
+// CourseRequest is the body used to create or update a course
+// swagger:model CourseRequest
+type CourseRequest struct {
+	// Catalog code, e.g. CS101. Stored upper-case.
+	Code string `json:"code" binding:"required,max=20"`
+
+	Title       string `json:"title" binding:"required,max=200"`
+	Description string `json:"description"`
+	Credits     *int   `json:"credits" binding:"required,min=0,max=60"`
+	Department  string `json:"department" binding:"required,max=100"`
+
+	// Staff emails owning the course. On create the caller is always an
+	// owner; on update an absent list keeps the current owners and an
+	// empty one is rejected.
+	Owners []string `json:"owners" binding:"omitempty,dive,email"`
+}
+
+func (r CourseRequest) course() repository.Course {
+	return repository.Course{
+		Code:        strings.ToUpper(strings.TrimSpace(r.Code)),
+		Title:       strings.TrimSpace(r.Title),
+		Description: r.Description,
+		Credits:     *r.Credits,
+		Department:  strings.TrimSpace(r.Department),
+	}
+}
+
+// pathID parses the :id parameter, aborting with 400 if it is not an
+// integer.
+func pathID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		problem.Invalid(c, problem.FieldError{Field: "id", Message: "must be an integer"})
+		return 0, false
+	}
+	return id, true
+}
+
+// ListCourses godoc
+// @Summary      List courses
+// @Description  Lists the course catalog ordered by code
+// @Tags         Courses
+// @Produce      json
+// @Param        department  query  string  false  "Only courses of this department"
+// @Param        limit       query  int     false  "Page size (1-100, default 20)"
+// @Param        offset      query  int     false  "Number of courses to skip"
+// @Success      200  {object}  Page[repository.Course]
+// @Failure      400  {object}  problem.Problem  "Invalid paging parameters"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Router       /courses [get]
+func (h *CourseHandler) ListCourses(c *gin.Context) {
+	page, err := parsePage(c)
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	courses, total, err := h.courses.ListCourses(ctx, repository.CourseFilter{
+		Department: c.Query("department"),
+		Page:       page,
+	})
+	if err != nil {
+		writeError(c, err, "Failed to load courses")
+		return
+	}
+	c.JSON(http.StatusOK, newPage(courses, total, page))
+}
+
+// CourseSearchResponse is a page of search hits with facet counts over
+// every hit
+// swagger:model CourseSearchResponse
+type CourseSearchResponse struct {
+	Page[repository.Course]
+	Facets repository.CourseFacets `json:"facets"`
+}
+
+// SearchCourses godoc
+// @Summary      Search courses
+// @Description  Full-text search over course codes, titles, descriptions and departments, best match first. Term, instructor and open-seat filters must all hold for the same offering. Facets count every matching course, not just the returned page.
+// @Tags         Courses
+// @Produce      json
+// @Param        q           query  string  false  "Keywords in web search syntax (quotes, OR, -word)"
+// @Param        department  query  string  false  "Only courses of this department"
+// @Param        credits     query  int     false  "Only courses worth this many credits"
+// @Param        term        query  int     false  "Only courses offered in this term"
+// @Param        instructor  query  string  false  "Only courses taught by this staff email"
+// @Param        open        query  bool    false  "Only courses with an offering that has a free seat"
+// @Param        limit       query  int     false  "Page size (1-100, default 20)"
+// @Param        offset      query  int     false  "Number of courses to skip"
+// @Success      200  {object}  CourseSearchResponse
+// @Failure      400  {object}  problem.Problem  "Invalid filters"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Router       /courses/search [get]
+func (h *CourseHandler) SearchCourses(c *gin.Context) {
+	search, err := parseCourseSearch(c)
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	result, err := h.courses.SearchCourses(ctx, search)
+	if err != nil {
+		writeError(c, err, "Failed to search courses")
+		return
+	}
+	c.JSON(http.StatusOK, CourseSearchResponse{
+		Page:   newPage(result.Courses, result.Total, search.Page),
+		Facets: result.Facets,
+	})
+}
+
+func parseCourseSearch(c *gin.Context) (repository.CourseSearch, error) {
+	page, err := parsePage(c)
+	errs := []error{err}
+
+	s := repository.CourseSearch{
+		Query:      strings.TrimSpace(c.Query("q")),
+		Department: c.Query("department"),
+		Instructor: c.Query("instructor"),
+		Page:       page,
+	}
+	if v := c.Query("credits"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			errs = append(errs, problem.FieldError{Field: "credits", Message: "must be a non-negative integer"})
+		}
+		s.Credits = &n
+	}
+	if v := c.Query("term"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			errs = append(errs, problem.FieldError{Field: "term", Message: "must be a term ID"})
+		}
+		s.TermID = n
+	}
+	if v := c.Query("open"); v != "" {
+		open, err := strconv.ParseBool(v)
+		if err != nil {
+			errs = append(errs, problem.FieldError{Field: "open", Message: "must be true or false"})
+		}
+		s.OpenSeats = open
+	}
+	return s, errors.Join(errs...)
+}
+
+// GetCourse godoc
+// @Summary      Get a course
+// @Tags         Courses
+// @Produce      json
+// @Param        id   path  int  true  "Course ID"
+// @Success      200  {object}  repository.Course
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Course not found"
+// @Router       /courses/{id} [get]
+func (h *CourseHandler) GetCourse(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	course, err := h.courses.GetCourse(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load course")
+		return
+	}
+	c.JSON(http.StatusOK, course)
+}
+
+// CreateCourse godoc
+// @Summary      Create a course
+// @Description  Adds a course to the catalog, owned by the caller and any listed staff
+// @Tags         Courses
+// @Accept       json
+// @Produce      json
+// @Param        body  body      CourseRequest  true  "Course"
+// @Success      201   {object}  repository.Course
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Owner is not a staff member"
+// @Failure      409   {object}  problem.Problem  "Course code already in use"
+// @Router       /courses [post]
+func (h *CourseHandler) CreateCourse(c *gin.Context) {
+	var body CourseRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	owners := []string{user.Email}
+	for _, email := range body.Owners {
+		if !slices.Contains(owners, email) {
+			owners = append(owners, email)
+		}
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	course, err := h.courses.CreateCourse(ctx, body.course(), owners)
+	if err != nil {
+		writeError(c, err, "Failed to create course")
+		return
+	}
+	c.JSON(http.StatusCreated, course)
+}
+
+// UpdateCourse godoc
+// @Summary      Update a course
+// @Description  Replaces a course's details. Only its owners and course administrators (registrar, admin, cto) may change it.
+// @Tags         Courses
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int            true  "Course ID"
+// @Param        body  body      CourseRequest  true  "Course"
+// @Success      200   {object}  repository.Course
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions or not an owner"
+// @Failure      404   {object}  problem.Problem  "Course not found, or owner is not a staff member"
+// @Failure      409   {object}  problem.Problem  "Course code already in use"
+// @Router       /courses/{id} [put]
+func (h *CourseHandler) UpdateCourse(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body CourseRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	// Without owners only course administrators could edit the course.
+	if body.Owners != nil && len(body.Owners) == 0 {
+		problem.Invalid(c, problem.FieldError{Field: "owners", Message: "must not be empty; omit it to keep the current owners"})
+		return
+	}
+
+	if !h.authorizeChange(c, id) {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	course := body.course()
+	course.ID = id
+	course, err := h.courses.UpdateCourse(ctx, course, body.Owners)
+	if err != nil {
+		writeError(c, err, "Failed to update course")
+		return
+	}
+	c.JSON(http.StatusOK, course)
+}
+
+// DeleteCourse godoc
+// @Summary      Delete a course
+// @Description  Removes a course from the catalog. Only its owners and course administrators may delete it.
+// @Tags         Courses
+// @Produce      json
+// @Param        id   path  int  true  "Course ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions or not an owner"
+// @Failure      404  {object}  problem.Problem  "Course not found"
+// @Failure      409  {object}  problem.Problem  "Course is still referenced"
+// @Router       /courses/{id} [delete]
+func (h *CourseHandler) DeleteCourse(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	if !h.authorizeChange(c, id) {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if err := h.courses.DeleteCourse(ctx, id); err != nil {
+		writeError(c, err, "Failed to delete course")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Course deleted"})
+}
+
+// authorizeChange aborts unless the current user may change course id.
+func (h *CourseHandler) authorizeChange(c *gin.Context, id int) bool {
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return false
+	}
+	if slices.Contains(courseAdminRoles, user.Role) {
+		return true
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	course, err := h.courses.GetCourse(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load course")
+		return false
+	}
+	if !course.IsOwner(user.Email) {
+		problem.Abort(c, problem.CourseNotOwner, "")
+		return false
+	}
+	return true
+}
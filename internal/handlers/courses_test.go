@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/middleware"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCreateCourse_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	for _, body := range []string{
+		`not json`,
+		`{"title": "Intro", "credits": 3, "department": "CS"}`,
+		`{"code": "CS101", "title": "Intro", "department": "CS"}`,
+		`{"code": "CS101", "title": "Intro", "credits": 61, "department": "CS"}`,
+		`{"code": "CS101", "title": "Intro", "credits": 3, "department": "CS", "owners": ["nobody"]}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/courses", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set(string(middleware.CurrentUserKey), &User{Email: "ruth@school.edu", Role: "teacher"})
+
+		h.CreateCourse(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestUpdateCourse_EmptyOwners(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Request = httptest.NewRequest("PUT", "/api/courses/1",
+		strings.NewReader(`{"code": "CS101", "title": "Intro", "credits": 3, "department": "CS", "owners": []}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set(string(middleware.CurrentUserKey), &User{Email: "ruth@school.edu", Role: "registrar"})
+
+	h.UpdateCourse(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400 for an empty owner list, got %d", w.Code)
+	}
+}
+
+func TestCourseChanges_OwnersOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	users := repository.NewMemoryUserRepository()
+	users.AddStaff(repository.StaffRow{FirstName: "Ruth", LastName: "Njeri", Email: "ruth@school.edu", Role: "teacher"})
+	users.AddStaff(repository.StaffRow{FirstName: "Peter", LastName: "Mwangi", Email: "peter@school.edu", Role: "teacher"})
+	courses := repository.NewMemoryCourseRepository(users)
+	course, err := courses.CreateCourse(context.Background(), repository.Course{
+		Code: "CS101", Title: "Intro", Credits: 3, Department: "CS",
+	}, []string{"ruth@school.edu"})
+	if err != nil {
+		t.Fatalf("CreateCourse: %v", err)
+	}
+	h := NewCourseHandler(courses, nil, nil)
+
+	for _, tc := range []struct {
+		email, role string
+		want        int
+	}{
+		{"peter@school.edu", "teacher", http.StatusForbidden},
+		{"RUTH@school.edu", "teacher", http.StatusOK},
+		{"grace@school.edu", "registrar", http.StatusOK},
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: fmt.Sprint(course.ID)}}
+		c.Request = httptest.NewRequest("PUT", "/api/courses/1",
+			strings.NewReader(`{"code": "CS101", "title": "Intro to Programming", "credits": 3, "department": "CS"}`))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set(string(middleware.CurrentUserKey), &User{Email: tc.email, Role: tc.role})
+
+		h.UpdateCourse(c)
+
+		if w.Code != tc.want {
+			t.Errorf("Expected %d for %s, got %d: %s", tc.want, tc.email, w.Code, w.Body.String())
+		}
+	}
+}
+
+func TestListCourses_InvalidPage(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	for _, query := range []string{"limit=0", "limit=101", "limit=x", "offset=-1"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/courses?"+query, nil)
+
+		h.ListCourses(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestSetRequisites_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	for _, body := range []string{
+		`{}`,
+		`{"groups": [{"kind": "sometimes", "options": [{"code": "CS101"}]}]}`,
+		`{"groups": [{"kind": "prerequisite", "options": []}]}`,
+		`{"groups": [{"kind": "prerequisite", "options": [{"code": "CS101", "min_grade": "F"}]}]}`,
+		`{"groups": [{"kind": "prerequisite", "options": [{"code": "CS101"}, {"code": "cs101"}]}]}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+		c.Request = httptest.NewRequest("PUT", "/api/courses/1/requisites", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set(string(middleware.CurrentUserKey), &User{Email: "ruth@school.edu", Role: "registrar"})
+
+		h.SetRequisites(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestRecordGrade_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	for _, body := range []string{
+		`{"grade": "B"}`,
+		`{"student": "not-an-email", "grade": "B"}`,
+		`{"student": "amani@school.edu", "grade": "E"}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+		c.Request = httptest.NewRequest("POST", "/api/courses/1/grades", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set(string(middleware.CurrentUserKey), &User{Email: "ruth@school.edu", Role: "registrar"})
+
+		h.RecordGrade(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestCheckEligibility_StudentsOnlySeeThemselves(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Request = httptest.NewRequest("GET", "/api/courses/1/eligibility?student=other@school.edu", nil)
+	c.Set(string(middleware.CurrentUserKey), &User{Email: "amani@school.edu", Role: "student"})
+
+	h.CheckEligibility(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", w.Code)
+	}
+}
+
+func TestCheckEligibility_OwnEmailInOtherCase(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	users := repository.NewMemoryUserRepository()
+	users.AddStudent(repository.StudentRow{FirstName: "Amani", LastName: "Wanjiru", Email: "amani@school.edu"})
+	courses := repository.NewMemoryCourseRepository(users)
+	requisites := repository.NewMemoryRequisiteRepository(courses)
+	records := repository.NewMemoryRecordRepository(users, courses)
+	for _, code := range []string{"CS101", "CS201"} {
+		if _, err := courses.CreateCourse(ctx, repository.Course{Code: code, Title: code, Credits: 3, Department: "CS"}, nil); err != nil {
+			t.Fatalf("CreateCourse %s: %v", code, err)
+		}
+	}
+	if _, err := requisites.SetRequisites(ctx, 2, []repository.RequisiteGroup{
+		{Kind: repository.Prerequisite, Options: []repository.Requirement{{Code: "CS101"}}},
+	}); err != nil {
+		t.Fatalf("SetRequisites: %v", err)
+	}
+	if _, err := records.RecordGrade(ctx, "amani@school.edu", 1, "B"); err != nil {
+		t.Fatalf("RecordGrade: %v", err)
+	}
+	h := NewCourseHandler(courses, requisites, records)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "2"}}
+	c.Request = httptest.NewRequest("GET", "/api/courses/2/eligibility?student=Amani@School.edu", nil)
+	c.Set(string(middleware.CurrentUserKey), &User{Email: "amani@school.edu", Role: "student"})
+
+	h.CheckEligibility(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for the student's own email in other case, got %d: %s", w.Code, w.Body)
+	}
+	if !strings.Contains(w.Body.String(), `"eligible":true`) {
+		t.Errorf("Expected the recorded B in CS101 to make amani eligible, got %s", w.Body)
+	}
+}
+
+func TestSearchCourses_InvalidFilters(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewCourseHandler(nil, nil, nil)
+
+	for _, query := range []string{"credits=-1", "credits=three", "term=0", "open=maybe", "limit=500"} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/courses/search?q=algebra&"+query, nil)
+
+		h.SearchCourses(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", query, w.Code)
+		}
+	}
+}
+
+func TestParseCourseSearch_ZeroCredits(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	for query, want := range map[string]*int{"": nil, "credits=0": new(int)} {
+		c, _ := gin.CreateTestContext(httptest.NewRecorder())
+		c.Request = httptest.NewRequest("GET", "/api/courses/search?"+query, nil)
+
+		s, err := parseCourseSearch(c)
+		if err != nil {
+			t.Fatalf("%q: %v", query, err)
+		}
+		if (s.Credits == nil) != (want == nil) || (want != nil && *s.Credits != *want) {
+			t.Errorf("%q: expected credits %v, got %v", query, want, s.Credits)
+		}
+	}
+}
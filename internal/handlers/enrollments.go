@@ -0,0 +1,274 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"time"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnrollmentHandler serves enrollment in course offerings and the
+// notifications it causes.
+type EnrollmentHandler struct {
+	enrollments   repository.EnrollmentRepository
+	notifications repository.NotificationRepository
+}
+
+func NewEnrollmentHandler(enrollments repository.EnrollmentRepository, notifications repository.NotificationRepository) *EnrollmentHandler {
+	return &EnrollmentHandler{enrollments: enrollments, notifications: notifications}
+}
+
+// EnrollRequest is the optional body of an enrollment
+// swagger:model EnrollRequest
+type EnrollRequest struct {
+	// Join the waitlist if the offering is full
+	Waitlist bool `json:"waitlist"`
+}
+
+// Enroll godoc
+// @Summary      Enroll in an offering
+// @Description  Takes a seat in a course offering for the calling student. When the offering is full the student joins its waitlist if asked to, and is promoted automatically, oldest request first, when a seat frees up.
+// @Tags         Enrollment
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int            true   "Offering ID"
+// @Param        body  body      EnrollRequest  false  "Options"
+// @Success      201   {object}  repository.Enrollment  "Enrolled or waitlisted"
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Only students may enroll, or enrollment is not open to the student"
+// @Failure      404   {object}  problem.Problem  "Offering or student not found"
+// @Failure      409   {object}  problem.Problem  "Already enrolled, the offering is full, or it clashes with the student's timetable"
+// @Router       /offerings/{id}/enrollment [post]
+func (h *EnrollmentHandler) Enroll(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body EnrollRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		problem.Invalid(c, err)
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	enrollment, err := h.enrollments.Enroll(ctx, id, user.Email, body.Waitlist)
+	if err != nil {
+		writeError(c, err, "Failed to enroll")
+		return
+	}
+	c.JSON(http.StatusCreated, enrollment)
+}
+
+// Drop godoc
+// @Summary      Drop an offering
+// @Description  Ends the calling student's enrollment or waitlist place. A freed seat goes to the next student on the waitlist, who is notified. Enrolled students cannot drop after the term's add/drop deadline; waitlist places can always be given up.
+// @Tags         Enrollment
+// @Produce      json
+// @Param        id   path  int  true  "Offering ID"
+// @Success      200  {object}  repository.Enrollment  "The dropped enrollment"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Only students may drop, or the add/drop deadline has passed"
+// @Failure      404  {object}  problem.Problem  "Offering or enrollment not found"
+// @Router       /offerings/{id}/enrollment [delete]
+func (h *EnrollmentHandler) Drop(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	result, err := h.enrollments.Drop(ctx, id, user.Email)
+	if err != nil {
+		writeError(c, err, "Failed to drop enrollment")
+		return
+	}
+	if len(result.Promoted) > 0 {
+		slog.InfoContext(ctx, "waitlist promoted", "offering_id", id, "promoted", len(result.Promoted))
+	}
+	c.JSON(http.StatusOK, result.Dropped)
+}
+
+// queryTermID reads the optional term query parameter, zero when absent.
+func queryTermID(c *gin.Context) (int, bool) {
+	v := c.Query("term")
+	if v == "" {
+		return 0, true
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil || n < 1 {
+		problem.Invalid(c, problem.FieldError{Field: "term", Message: "must be a term ID"})
+		return 0, false
+	}
+	return n, true
+}
+
+// MyEnrollments godoc
+// @Summary      List my enrollments
+// @Description  Lists the calling student's enrollments and waitlist places
+// @Tags         Enrollment
+// @Produce      json
+// @Param        term  query  int  false  "Only this term"
+// @Success      200  {array}   repository.Enrollment
+// @Failure      400  {object}  problem.Problem  "Invalid term"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Router       /me/enrollments [get]
+func (h *EnrollmentHandler) MyEnrollments(c *gin.Context) {
+	termID, ok := queryTermID(c)
+	if !ok {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	enrollments, err := h.enrollments.StudentEnrollments(ctx, user.Email, termID)
+	if err != nil {
+		writeError(c, err, "Failed to load enrollments")
+		return
+	}
+	c.JSON(http.StatusOK, enrollments)
+}
+
+// MyNotifications godoc
+// @Summary      List my notifications
+// @Description  Lists the caller's notifications, newest first
+// @Tags         Enrollment
+// @Produce      json
+// @Param        unread  query  bool  false  "Only unread notifications"
+// @Success      200  {array}   repository.Notification
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Router       /me/notifications [get]
+func (h *EnrollmentHandler) MyNotifications(c *gin.Context) {
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	notifications, err := h.notifications.ListNotifications(ctx, user.Email, c.Query("unread") == "true")
+	if err != nil {
+		writeError(c, err, "Failed to load notifications")
+		return
+	}
+	c.JSON(http.StatusOK, notifications)
+}
+
+// MarkNotificationRead godoc
+// @Summary      Mark a notification read
+// @Tags         Enrollment
+// @Produce      json
+// @Param        id   path  int  true  "Notification ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Notification not found"
+// @Router       /me/notifications/{id}/read [post]
+func (h *EnrollmentHandler) MarkNotificationRead(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if err := h.notifications.MarkRead(ctx, user.Email, id); err != nil {
+		writeError(c, err, "Failed to update notification")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Notification marked read"})
+}
+
+// TimetableDay is one weekday of a timetable
+// swagger:model TimetableDay
+type TimetableDay struct {
+	// ISO weekday: 1 is Monday, 7 is Sunday
+	Weekday  int                         `json:"weekday"`
+	Day      string                      `json:"day"`
+	Meetings []repository.TimetableEntry `json:"meetings"`
+}
+
+// timetableDays groups entries, ordered by weekday, into days.
+func timetableDays(entries []repository.TimetableEntry) []TimetableDay {
+	days := []TimetableDay{}
+	for _, e := range entries {
+		if n := len(days); n == 0 || days[n-1].Weekday != e.Weekday {
+			days = append(days, TimetableDay{Weekday: e.Weekday, Day: time.Weekday(e.Weekday % 7).String()})
+		}
+		day := &days[len(days)-1]
+		day.Meetings = append(day.Meetings, e)
+	}
+	return days
+}
+
+// MyTimetable godoc
+// @Summary      Get my weekly timetable
+// @Description  Lists the weekly meetings of the offerings the caller is enrolled or waitlisted in, or teaches, grouped by weekday
+// @Tags         Enrollment
+// @Produce      json
+// @Param        term  query  int  false  "Term ID; defaults to the term in progress"
+// @Success      200  {array}   TimetableDay
+// @Failure      400  {object}  problem.Problem  "Invalid term"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Term not found"
+// @Router       /me/timetable [get]
+func (h *EnrollmentHandler) MyTimetable(c *gin.Context) {
+	termID, ok := queryTermID(c)
+	if !ok {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	entries, err := h.enrollments.Timetable(ctx, user.Email, termID)
+	if err != nil {
+		writeError(c, err, "Failed to load timetable")
+		return
+	}
+	c.JSON(http.StatusOK, timetableDays(entries))
+}
@@ -0,0 +1,68 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestEnroll_InvalidRequest(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewEnrollmentHandler(nil, nil)
+
+	for _, tc := range []struct{ id, body string }{
+		{"abc", ``},
+		{"1", `{"waitlist": "yes"}`},
+		{"1", `not json`},
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: tc.id}}
+		c.Request = httptest.NewRequest("POST", "/api/offerings/"+tc.id+"/enrollment", strings.NewReader(tc.body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.Enroll(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for id %q body %q, got %d", tc.id, tc.body, w.Code)
+		}
+	}
+}
+
+func TestMyEnrollments_InvalidTerm(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewEnrollmentHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("GET", "/api/me/enrollments?term=spring", nil)
+
+	h.MyEnrollments(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+}
+
+func TestTimetableDays(t *testing.T) {
+	entry := func(weekday int, start string) repository.TimetableEntry {
+		return repository.TimetableEntry{ScheduledMeeting: repository.ScheduledMeeting{
+			Meeting: repository.Meeting{Weekday: weekday, StartsAt: start},
+		}}
+	}
+
+	days := timetableDays([]repository.TimetableEntry{entry(1, "09:00"), entry(1, "14:00"), entry(7, "10:00")})
+	if len(days) != 2 || days[0].Day != "Monday" || len(days[0].Meetings) != 2 || days[1].Day != "Sunday" {
+		t.Errorf("Expected Monday with two meetings and Sunday, got %+v", days)
+	}
+	if days := timetableDays(nil); days == nil || len(days) != 0 {
+		t.Errorf("Expected an empty timetable, got %+v", days)
+	}
+}
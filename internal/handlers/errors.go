@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"time"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// requestTimeout bounds how long a handler waits on the database or Google
+// before giving up with 503.
+const requestTimeout = 5 * time.Second
+
+// requestContext derives a context from the incoming request so work stops
+// when the client goes away or the deadline passes.
+func requestContext(c *gin.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(c.Request.Context(), requestTimeout)
+}
+
+// errorCode maps repository errors onto problem codes, preferring the most
+// specific code that applies.
+func errorCode(err error) problem.Code {
+	switch {
+	case errors.Is(err, repository.ErrStaffNotFound):
+		return problem.StaffNotFound
+	case errors.Is(err, repository.ErrRoleGrantNotFound):
+		return problem.RoleGrantNotFound
+	case errors.Is(err, repository.ErrRoleRequestNotFound):
+		return problem.RoleRequestNotFound
+	case errors.Is(err, repository.ErrStudentNotFound):
+		return problem.StudentNotFound
+	case errors.Is(err, repository.ErrCourseNotFound):
+		return problem.CourseNotFound
+	case errors.Is(err, repository.ErrTermNotFound):
+		return problem.TermNotFound
+	case errors.Is(err, repository.ErrOfferingNotFound):
+		return problem.OfferingNotFound
+	case errors.Is(err, repository.ErrEnrollmentNotFound):
+		return problem.EnrollmentNotFound
+	case errors.Is(err, repository.ErrNotificationNotFound):
+		return problem.NotificationNotFound
+	case errors.Is(err, repository.ErrOverrideNotFound):
+		return problem.OverrideNotFound
+	case errors.Is(err, repository.ErrNotFound):
+		return problem.NotFound
+	case errors.Is(err, repository.ErrRequestNotPending):
+		return problem.RoleRequestNotPending
+	case errors.Is(err, repository.ErrRequestExpired):
+		return problem.RoleRequestExpired
+	case errors.Is(err, repository.ErrCourseCodeTaken):
+		return problem.CourseCodeTaken
+	case errors.Is(err, repository.ErrCourseInUse):
+		return problem.CourseInUse
+	case errors.Is(err, repository.ErrRequisiteCycle):
+		return problem.RequisiteCycle
+	case errors.Is(err, repository.ErrTermCodeTaken):
+		return problem.TermCodeTaken
+	case errors.Is(err, repository.ErrSectionTaken):
+		return problem.SectionTaken
+	case errors.Is(err, repository.ErrOfferingHasEnrollments):
+		return problem.OfferingInUse
+	case errors.Is(err, repository.ErrAlreadyEnrolled):
+		return problem.AlreadyEnrolled
+	case errors.Is(err, repository.ErrOfferingFull):
+		return problem.OfferingFull
+	case errors.Is(err, repository.ErrEnrollmentNotOpen):
+		return problem.EnrollmentNotOpen
+	case errors.Is(err, repository.ErrEnrollmentClosed):
+		return problem.EnrollmentClosed
+	case errors.Is(err, repository.ErrAddDropClosed):
+		return problem.AddDropClosed
+	case errors.Is(err, repository.ErrTimetableClash):
+		return problem.TimetableClash
+	case errors.Is(err, repository.ErrRoomBooked):
+		return problem.RoomBooked
+	case errors.Is(err, repository.ErrConflict):
+		return problem.Conflict
+	case errors.Is(err, repository.ErrSelfApproval):
+		return problem.SelfApproval
+	case repository.IsUnavailable(err):
+		return problem.Unavailable
+	}
+	return problem.Internal
+}
+
+// errorStatus maps repository errors onto HTTP status codes.
+func errorStatus(err error) int {
+	return errorCode(err).Status
+}
+
+// writeError responds with the problem matching err. Domain errors are
+// safe to show to the client; anything else is logged and replaced by
+// message. Clashes also name the meeting they clashed with.
+func writeError(c *gin.Context, err error, message string) {
+	code := errorCode(err)
+	switch code {
+	case problem.Internal:
+		slog.ErrorContext(c.Request.Context(), message, "error", err)
+		problem.Abort(c, code, message)
+	case problem.Unavailable:
+		slog.WarnContext(c.Request.Context(), "dependency unavailable", "error", err)
+		problem.Abort(c, code, "")
+	default:
+		p := problem.New(code, err.Error())
+		var clash *repository.ClashError
+		if errors.As(err, &clash) {
+			p.Conflict = clash.Clash.With
+		}
+		problem.Write(c, p)
+	}
+}
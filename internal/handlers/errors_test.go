@@ -0,0 +1,89 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestErrorStatus(t *testing.T) {
+	cases := map[error]int{
+		repository.ErrStaffNotFound:                                 http.StatusNotFound,
+		fmt.Errorf("wrapped: %w", repository.ErrNotFound):           http.StatusNotFound,
+		repository.ErrCourseNotFound:                                http.StatusNotFound,
+		repository.ErrCourseCodeTaken:                               http.StatusConflict,
+		repository.ErrOfferingFull:                                  http.StatusConflict,
+		repository.ErrOfferingHasEnrollments:                        http.StatusConflict,
+		repository.ErrEnrollmentClosed:                              http.StatusForbidden,
+		repository.ErrAddDropClosed:                                 http.StatusForbidden,
+		&repository.ClashError{Kind: repository.ErrTimetableClash}:  http.StatusConflict,
+		repository.ErrNotificationNotFound:                          http.StatusNotFound,
+		repository.ErrSelfApproval:                                  http.StatusForbidden,
+		repository.ErrRequestExpired:                                http.StatusConflict,
+		repository.ErrRequestNotPending:                             http.StatusConflict,
+		&repository.UnavailableError{Err: context.DeadlineExceeded}: http.StatusServiceUnavailable,
+		errors.New("syntax error at or near SELECT"):                http.StatusInternalServerError,
+	}
+	for err, want := range cases {
+		if got := errorStatus(err); got != want {
+			t.Errorf("%v: expected %d, got %d", err, want, got)
+		}
+	}
+}
+
+func TestWriteError_ClashNamesConflict(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	booked := repository.ScheduledMeeting{
+		OfferingID: 7,
+		CourseCode: "MA101",
+		Section:    "A",
+		Meeting:    repository.Meeting{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00", Room: "LT1"},
+	}
+	err := &repository.ClashError{
+		Kind: repository.ErrRoomBooked,
+		Clash: repository.Clash{
+			Meeting: repository.Meeting{Weekday: 1, StartsAt: "09:00", EndsAt: "10:30", Room: "LT1"},
+			With:    booked,
+		},
+	}
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/offerings", nil)
+
+	writeError(c, err, "Failed to create offering")
+
+	if w.Code != http.StatusConflict {
+		t.Fatalf("Expected 409, got %d: %s", w.Code, w.Body.String())
+	}
+	var body struct {
+		Code     string                      `json:"code"`
+		Conflict repository.ScheduledMeeting `json:"conflict"`
+	}
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+		t.Fatalf("Invalid JSON: %v", err)
+	}
+	if body.Code != "offering.room_booked" || body.Conflict != booked {
+		t.Errorf("Expected the booked meeting as the conflict, got %s", w.Body.String())
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/offerings", nil)
+
+	writeError(c, repository.ErrSectionTaken, "Failed to create offering")
+
+	if strings.Contains(w.Body.String(), `"conflict"`) {
+		t.Errorf("Expected no conflict member for other errors, got %s", w.Body.String())
+	}
+}
@@ -0,0 +1,53 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"elimu-go/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+// HealthHandler serves the liveness and readiness probes.
+type HealthHandler struct {
+	registry *health.Registry
+}
+
+func NewHealthHandler(registry *health.Registry) *HealthHandler {
+	return &HealthHandler{registry: registry}
+}
+
+// Live godoc
+// @Summary      Liveness probe
+// @Description  Reports that the process is running. Does not check dependencies.
+// @Tags         General
+// @Produce      json
+// @Success      200  {object}  health.Report
+// @Router       /health/live [get]
+func (h *HealthHandler) Live(c *gin.Context) {
+	c.JSON(http.StatusOK, health.Report{
+		Status:    health.StatusUp,
+		Checks:    []health.Result{},
+		Timestamp: time.Now().Unix(),
+	})
+}
+
+// Ready godoc
+// @Summary      Readiness probe
+// @Description  Runs every dependency check. Returns 503 when a critical check fails, including while the server is shutting down.
+// @Tags         General
+// @Produce      json
+// @Success      200  {object}  health.Report  "All critical checks pass"
+// @Failure      503  {object}  health.Report  "A critical check failed"
+// @Router       /health/ready [get]
+// @Router       /health [get]
+func (h *HealthHandler) Ready(c *gin.Context) {
+	report := h.registry.Run(c.Request.Context())
+
+	status := http.StatusOK
+	if !report.Healthy() {
+		status = http.StatusServiceUnavailable
+	}
+	c.JSON(status, report)
+}
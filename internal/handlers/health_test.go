@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"elimu-go/internal/health"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestHealthReady(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	var dbErr error
+	registry := health.NewRegistry()
+	registry.Register(health.Check{
+		Name:     "postgres",
+		Critical: true,
+		Run:      func(context.Context) error { return dbErr },
+	})
+	h := NewHealthHandler(registry)
+
+	ready := func() (int, health.Report) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("GET", "/api/health/ready", nil)
+		h.Ready(c)
+
+		var report health.Report
+		json.Unmarshal(w.Body.Bytes(), &report)
+		return w.Code, report
+	}
+
+	if code, report := ready(); code != http.StatusOK || report.Checks[0].Status != health.StatusUp {
+		t.Errorf("Expected 200 with postgres up, got %d %+v", code, report)
+	}
+
+	dbErr = errors.New("connection refused")
+	if code, report := ready(); code != http.StatusServiceUnavailable || report.Checks[0].Error == "" {
+		t.Errorf("Expected 503 with postgres error, got %d %+v", code, report)
+	}
+}
+
+func TestHealthLive(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+
+	NewHealthHandler(health.NewRegistry()).Live(c)
+
+	if w.Code != http.StatusOK {
+		t.Errorf("Live should return 200, got %d", w.Code)
+	}
+}
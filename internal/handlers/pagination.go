@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"errors"
+	"strconv"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Page sizes for list endpoints.
+const (
+	defaultPageLimit = 20
+	maxPageLimit     = 100
+)
+
+// Page is one page of a list endpoint's results.
+type Page[T any] struct {
+	Items  []T `json:"items"`
+	Total  int `json:"total"`
+	Limit  int `json:"limit"`
+	Offset int `json:"offset"`
+}
+
+func newPage[T any](items []T, total int, p repository.Page) Page[T] {
+	if items == nil {
+		items = []T{}
+	}
+	return Page[T]{Items: items, Total: total, Limit: p.Limit, Offset: p.Offset}
+}
+
+// parsePage reads the limit and offset query parameters.
+func parsePage(c *gin.Context) (repository.Page, error) {
+	p := repository.Page{Limit: defaultPageLimit}
+	var errs []error
+
+	if v := c.Query("limit"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 || n > maxPageLimit {
+			errs = append(errs, problem.FieldError{Field: "limit", Message: "must be between 1 and " + strconv.Itoa(maxPageLimit)})
+		}
+		p.Limit = n
+	}
+	if v := c.Query("offset"); v != "" {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 0 {
+			errs = append(errs, problem.FieldError{Field: "offset", Message: "must be a non-negative integer"})
+		}
+		p.Offset = n
+	}
+
+	return p, errors.Join(errs...)
+}
@@ -0,0 +1,255 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RequisitesRequest replaces a course's requisites. All groups must be met;
+// a group is met by any one of its options.
+// swagger:model RequisitesRequest
+type RequisitesRequest struct {
+	Groups []RequisiteGroupRequest `json:"groups" binding:"required,dive"`
+}
+
+// RequisiteGroupRequest is one group of alternatives
+// swagger:model RequisiteGroupRequest
+type RequisiteGroupRequest struct {
+	// prerequisite or corequisite
+	Kind    string               `json:"kind" binding:"required,oneof=prerequisite corequisite"`
+	Options []RequirementRequest `json:"options" binding:"required,min=1,dive"`
+}
+
+// RequirementRequest names a required course
+// swagger:model RequirementRequest
+type RequirementRequest struct {
+	Code string `json:"code" binding:"required,max=20"`
+
+	// Lowest acceptable letter grade, e.g. C+. Empty accepts any pass.
+	MinGrade string `json:"min_grade"`
+}
+
+// groups validates the request and converts it for the repository.
+func (r RequisitesRequest) groups() ([]repository.RequisiteGroup, error) {
+	var errs []error
+	groups := make([]repository.RequisiteGroup, len(r.Groups))
+	for i, g := range r.Groups {
+		groups[i].Kind = g.Kind
+		seen := make(map[string]bool)
+		for j, opt := range g.Options {
+			field := fmt.Sprintf("groups[%d].options[%d]", i, j)
+			code := strings.ToUpper(strings.TrimSpace(opt.Code))
+			if seen[code] {
+				errs = append(errs, problem.FieldError{Field: field + ".code", Message: "is listed twice in the group"})
+			}
+			seen[code] = true
+			if opt.MinGrade != "" && (!repository.ValidGrade(opt.MinGrade) || !repository.GradeAtLeast(opt.MinGrade, "")) {
+				errs = append(errs, problem.FieldError{Field: field + ".min_grade", Message: "is not a passing letter grade"})
+			}
+			groups[i].Options = append(groups[i].Options, repository.Requirement{Code: code, MinGrade: opt.MinGrade})
+		}
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	return groups, nil
+}
+
+// EligibilityResponse says whether a student may take a course
+// swagger:model EligibilityResponse
+type EligibilityResponse struct {
+	CourseID int    `json:"course_id"`
+	Student  string `json:"student"`
+	Eligible bool   `json:"eligible"`
+
+	// Requisite groups the student's record does not meet
+	Missing []repository.RequisiteGroup `json:"missing"`
+}
+
+// GradeRequest records a student's final grade in a course
+// swagger:model GradeRequest
+type GradeRequest struct {
+	Student string `json:"student" binding:"required,email"`
+
+	// Letter grade, e.g. B+
+	Grade string `json:"grade" binding:"required"`
+}
+
+// GetRequisites godoc
+// @Summary      Get course requisites
+// @Description  Lists the prerequisite and co-requisite groups of a course. Every group must be met; a group is met by any one of its options.
+// @Tags         Courses
+// @Produce      json
+// @Param        id   path  int  true  "Course ID"
+// @Success      200  {object}  repository.Requisites
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Course not found"
+// @Router       /courses/{id}/requisites [get]
+func (h *CourseHandler) GetRequisites(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	reqs, err := h.requisites.GetRequisites(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load requisites")
+		return
+	}
+	c.JSON(http.StatusOK, reqs)
+}
+
+// SetRequisites godoc
+// @Summary      Replace course requisites
+// @Description  Replaces every requisite group of a course. Changes that would make a course its own prerequisite, directly or through other courses, are rejected.
+// @Tags         Courses
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                true  "Course ID"
+// @Param        body  body      RequisitesRequest  true  "Requisites"
+// @Success      200   {object}  repository.Requisites
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions or not an owner"
+// @Failure      404   {object}  problem.Problem  "Course or required course not found"
+// @Failure      409   {object}  problem.Problem  "The requisites would form a cycle"
+// @Router       /courses/{id}/requisites [put]
+func (h *CourseHandler) SetRequisites(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body RequisitesRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	groups, err := body.groups()
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	if !h.authorizeChange(c, id) {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	reqs, err := h.requisites.SetRequisites(ctx, id, groups)
+	if err != nil {
+		writeError(c, err, "Failed to save requisites")
+		return
+	}
+	c.JSON(http.StatusOK, reqs)
+}
+
+// CheckEligibility godoc
+// @Summary      Check course eligibility
+// @Description  Checks a student's academic record against a course's requisites. Students may only check themselves; staff may name any student.
+// @Tags         Courses
+// @Produce      json
+// @Param        id       path   int     true   "Course ID"
+// @Param        student  query  string  false  "Student email (staff only, defaults to the caller)"
+// @Success      200  {object}  EligibilityResponse
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Students may only check themselves"
+// @Failure      404  {object}  problem.Problem  "Course or student not found"
+// @Router       /courses/{id}/eligibility [get]
+func (h *CourseHandler) CheckEligibility(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+	student := c.DefaultQuery("student", user.Email)
+	if strings.EqualFold(student, user.Email) {
+		student = user.Email
+	} else if user.Role == "student" {
+		problem.Abort(c, problem.Forbidden, "students may only check their own eligibility")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	reqs, err := h.requisites.GetRequisites(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load requisites")
+		return
+	}
+	record, err := h.records.StudentRecord(ctx, student)
+	if err != nil {
+		writeError(c, err, "Failed to load academic record")
+		return
+	}
+
+	missing := reqs.Missing(record)
+	c.JSON(http.StatusOK, EligibilityResponse{
+		CourseID: id,
+		Student:  student,
+		Eligible: len(missing) == 0,
+		Missing:  missing,
+	})
+}
+
+// RecordGrade godoc
+// @Summary      Record a grade
+// @Description  Adds a graded attempt of the course to a student's academic record, which eligibility checks read. Only the course's owners and course administrators may record grades.
+// @Tags         Courses
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int           true  "Course ID"
+// @Param        body  body      GradeRequest  true  "Grade"
+// @Success      201   {object}  repository.AcademicRecord
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions or not an owner"
+// @Failure      404   {object}  problem.Problem  "Course or student not found"
+// @Router       /courses/{id}/grades [post]
+func (h *CourseHandler) RecordGrade(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body GradeRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	grade := strings.ToUpper(strings.TrimSpace(body.Grade))
+	if !repository.ValidGrade(grade) {
+		problem.Invalid(c, problem.FieldError{Field: "grade", Message: "is not a letter grade"})
+		return
+	}
+
+	if !h.authorizeChange(c, id) {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	rec, err := h.records.RecordGrade(ctx, body.Student, id, grade)
+	if err != nil {
+		writeError(c, err, "Failed to record grade")
+		return
+	}
+	c.JSON(http.StatusCreated, rec)
+}
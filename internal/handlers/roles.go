@@ -0,0 +1,302 @@
+package handlers
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"elimu-go/internal/health"
+	"elimu-go/internal/middleware"
+	"elimu-go/internal/models"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// roleRequestTTL is how long a privileged role request waits for a second
+// admin before it expires.
+const roleRequestTTL = 48 * time.Hour
+
+// privilegedRoles can only be granted with four-eyes approval.
+var privilegedRoles = map[string]struct{}{
+	"admin": {},
+	"cto":   {},
+}
+
+func isPrivilegedRole(role string) bool {
+	_, ok := privilegedRoles[role]
+	return ok
+}
+
+// GrantRoleRequest is the body of a role grant
+// swagger:model GrantRoleRequest
+type GrantRoleRequest struct {
+	// Staff email to grant the role to
+	Email string `json:"email" binding:"required,email"`
+
+	// Role to grant
+	Role string `json:"role" binding:"required,max=50"`
+
+	// Optional time after which the grant reverts
+	ExpiresAt *time.Time `json:"expires_at"`
+
+	// Why the role is needed
+	Reason string `json:"reason"`
+}
+
+func currentUser(c *gin.Context) *models.User {
+	v, ok := c.Get(string(middleware.CurrentUserKey))
+	if !ok {
+		return nil
+	}
+	u, _ := v.(*models.User)
+	return u
+}
+
+// GrantRole godoc
+// @Summary      Grant a role
+// @Description  Grants a role to a staff member. Privileged roles (admin, cto) create a pending request that a second admin must approve.
+// @Tags         General
+// @Accept       json
+// @Produce      json
+// @Param        body  body      GrantRoleRequest  true  "Role grant"
+// @Success      201   {object}  repository.RoleGrant         "Role granted"
+// @Success      202   {object}  repository.RoleRequest       "Approval required"
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Staff member not found"
+// @Router       /admin/roles/grants [post]
+func (h *AdminHandler) GrantRole(c *gin.Context) {
+	var body GrantRoleRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	body.Role = strings.TrimSpace(body.Role)
+	if body.Role == "" || body.Role == "student" {
+		problem.Invalid(c, problem.FieldError{Field: "role", Message: "is not a staff role"})
+		return
+	}
+	if body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()) {
+		problem.Invalid(c, problem.FieldError{Field: "expires_at", Message: "must be in the future"})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if isPrivilegedRole(body.Role) {
+		req, err := h.roles.CreateRequest(ctx, repository.RoleRequest{
+			Email:          body.Email,
+			Role:           body.Role,
+			GrantExpiresAt: body.ExpiresAt,
+			Reason:         body.Reason,
+			RequestedBy:    user.Email,
+			ExpiresAt:      time.Now().Add(roleRequestTTL),
+		})
+		if err != nil {
+			writeError(c, err, "Database error")
+			return
+		}
+		c.JSON(http.StatusAccepted, req)
+		return
+	}
+
+	grant, err := h.roles.Grant(ctx, repository.RoleGrant{
+		Email:     body.Email,
+		Role:      body.Role,
+		GrantedBy: user.Email,
+		ExpiresAt: body.ExpiresAt,
+	})
+	if err != nil {
+		writeError(c, err, "Database error")
+		return
+	}
+	h.sessions.UpdateRole(grant.Email, grant.Role)
+
+	c.JSON(http.StatusCreated, grant)
+}
+
+// ListRoleGrants godoc
+// @Summary      List role grants
+// @Description  Lists role grants, newest first
+// @Tags         General
+// @Produce      json
+// @Param        email   query  string  false  "Only grants for this staff email"
+// @Param        active  query  bool    false  "Only grants that have not been revoked"
+// @Success      200  {array}   repository.RoleGrant
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Router       /admin/roles/grants [get]
+func (h *AdminHandler) ListRoleGrants(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	active := c.Query("active") == "true"
+	grants, err := h.roles.ListGrants(ctx, c.Query("email"), active)
+	if err != nil {
+		writeError(c, err, "Database error")
+		return
+	}
+	c.JSON(http.StatusOK, grants)
+}
+
+// RevokeRoleGrant godoc
+// @Summary      Revoke a role grant
+// @Description  Ends an active grant and restores the role the staff member held before it
+// @Tags         General
+// @Produce      json
+// @Param        id   path  int  true  "Grant ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404  {object}  problem.Problem  "Grant not found"
+// @Router       /admin/roles/grants/{id} [delete]
+func (h *AdminHandler) RevokeRoleGrant(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		problem.Invalid(c, problem.FieldError{Field: "id", Message: "must be an integer"})
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	change, err := h.roles.RevokeGrant(ctx, id)
+	if err != nil {
+		writeError(c, err, "Database error")
+		return
+	}
+	if change != nil {
+		h.sessions.UpdateRole(change.Email, change.Role)
+	}
+
+	c.JSON(http.StatusOK, gin.H{"message": "Role revoked"})
+}
+
+// ListRoleRequests godoc
+// @Summary      List role requests
+// @Description  Lists privileged role requests, newest first
+// @Tags         General
+// @Produce      json
+// @Param        status  query  string  false  "pending, approved, rejected or expired"
+// @Success      200  {array}   repository.RoleRequest
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Router       /admin/roles/requests [get]
+func (h *AdminHandler) ListRoleRequests(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	requests, err := h.roles.ListRequests(ctx, c.Query("status"))
+	if err != nil {
+		writeError(c, err, "Database error")
+		return
+	}
+	c.JSON(http.StatusOK, requests)
+}
+
+// ApproveRoleRequest godoc
+// @Summary      Approve a role request
+// @Description  Approves a pending privileged role request. The approver must be neither the requester nor the staff member being elevated.
+// @Tags         General
+// @Produce      json
+// @Param        id   path  int  true  "Request ID"
+// @Success      200  {object}  repository.RoleGrant
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions, or the requester or subject approving the request"
+// @Failure      404  {object}  problem.Problem  "Request not found"
+// @Failure      409  {object}  problem.Problem  "Request already decided or expired"
+// @Router       /admin/roles/requests/{id}/approve [post]
+func (h *AdminHandler) ApproveRoleRequest(c *gin.Context) {
+	h.decideRequest(c, true)
+}
+
+// RejectRoleRequest godoc
+// @Summary      Reject a role request
+// @Description  Rejects a pending privileged role request
+// @Tags         General
+// @Produce      json
+// @Param        id   path  int  true  "Request ID"
+// @Success      200  {object}  repository.RoleRequest
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions, or the requester or subject rejecting the request"
+// @Failure      404  {object}  problem.Problem  "Request not found"
+// @Failure      409  {object}  problem.Problem  "Request already decided or expired"
+// @Router       /admin/roles/requests/{id}/reject [post]
+func (h *AdminHandler) RejectRoleRequest(c *gin.Context) {
+	h.decideRequest(c, false)
+}
+
+func (h *AdminHandler) decideRequest(c *gin.Context, approve bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		problem.Invalid(c, problem.FieldError{Field: "id", Message: "must be an integer"})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	req, grant, err := h.roles.DecideRequest(ctx, id, user.Email, approve)
+	if err != nil {
+		writeError(c, err, "Database error")
+		return
+	}
+
+	if grant == nil {
+		c.JSON(http.StatusOK, req)
+		return
+	}
+
+	h.sessions.UpdateRole(grant.Email, grant.Role)
+	c.JSON(http.StatusOK, grant)
+}
+
+// RunRoleExpiry expires stale role requests and reverts time-bound grants
+// every interval until ctx is cancelled. hb, if set, beats after every
+// successful sweep.
+func (h *AdminHandler) RunRoleExpiry(ctx context.Context, interval time.Duration, hb *health.Heartbeat) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	hb.Beat()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		expired, changes, err := h.roles.Expire(ctx)
+		if err != nil {
+			slog.ErrorContext(ctx, "role expiry sweep failed", "error", err)
+			continue
+		}
+		for _, change := range changes {
+			h.sessions.UpdateRole(change.Email, change.Role)
+		}
+		hb.Beat()
+		if expired > 0 || len(changes) > 0 {
+			slog.InfoContext(ctx, "role expiry sweep", "expired_requests", expired, "reverted_grants", len(changes))
+		}
+	}
+}
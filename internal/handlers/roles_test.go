@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/middleware"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestGrantRole_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewAdminHandler(repository.NewMemoryUserRepository(), repository.NewMemorySessionRepository(), nil)
+
+	for _, body := range []string{
+		`not json`,
+		`{"email": "teacher@school.edu"}`,
+		`{"email": "not-an-email", "role": "teacher"}`,
+		`{"email": "teacher@school.edu", "role": "student"}`,
+		`{"email": "teacher@school.edu", "role": "teacher", "expires_at": "2001-01-01T00:00:00Z"}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/admin/roles/grants", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set(string(middleware.CurrentUserKey), &User{Email: "admin@school.edu", Role: "admin"})
+
+		h.GrantRole(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestRoleRequest_FourEyes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	users := repository.NewMemoryUserRepository()
+	users.AddStaff(repository.StaffRow{Email: "grace@school.edu", Role: "admin"})
+	users.AddStaff(repository.StaffRow{Email: "peter@school.edu", Role: "admin"})
+	users.AddStaff(repository.StaffRow{Email: "ruth@school.edu", Role: "teacher"})
+	h := NewAdminHandler(users, repository.NewMemorySessionRepository(), repository.NewMemoryRoleRepository(users))
+
+	call := func(handler gin.HandlerFunc, as, target, body string, params ...gin.Param) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", target, strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Params = params
+		c.Set(string(middleware.CurrentUserKey), &User{Email: as, Role: "admin"})
+		handler(c)
+		return w
+	}
+
+	w := call(h.GrantRole, "grace@school.edu", "/api/admin/roles/grants", `{"email": "ruth@school.edu", "role": "admin"}`)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 for a privileged grant, got %d: %s", w.Code, w.Body)
+	}
+
+	id := gin.Param{Key: "id", Value: "1"}
+	if w := call(h.ApproveRoleRequest, "Grace@School.edu", "/api/admin/roles/requests/1/approve", "", id); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for the requester approving in another case, got %d: %s", w.Code, w.Body)
+	}
+	if role, _ := users.FindRole(context.Background(), "ruth@school.edu"); role != "teacher" {
+		t.Errorf("Expected ruth to stay teacher before approval, got %q", role)
+	}
+
+	if w := call(h.ApproveRoleRequest, "peter@school.edu", "/api/admin/roles/requests/1/approve", "", id); w.Code != http.StatusOK {
+		t.Fatalf("Expected 200 for a second admin approving, got %d: %s", w.Code, w.Body)
+	}
+	if role, _ := users.FindRole(context.Background(), "ruth@school.edu"); role != "admin" {
+		t.Errorf("Expected ruth to be admin after approval, got %q", role)
+	}
+	if w := call(h.ApproveRoleRequest, "peter@school.edu", "/api/admin/roles/requests/1/approve", "", id); w.Code != http.StatusConflict {
+		t.Errorf("Expected 409 deciding twice, got %d", w.Code)
+	}
+}
+
+func TestRoleRequest_SubjectCannotApprove(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	users := repository.NewMemoryUserRepository()
+	users.AddStaff(repository.StaffRow{Email: "grace@school.edu", Role: "admin"})
+	users.AddStaff(repository.StaffRow{Email: "peter@school.edu", Role: "admin"})
+	h := NewAdminHandler(users, repository.NewMemorySessionRepository(), repository.NewMemoryRoleRepository(users))
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/admin/roles/grants", strings.NewReader(`{"email": "peter@school.edu", "role": "cto"}`))
+	c.Request.Header.Set("Content-Type", "application/json")
+	c.Set(string(middleware.CurrentUserKey), &User{Email: "grace@school.edu", Role: "admin"})
+	h.GrantRole(c)
+	if w.Code != http.StatusAccepted {
+		t.Fatalf("Expected 202 for a privileged grant, got %d: %s", w.Code, w.Body)
+	}
+
+	w = httptest.NewRecorder()
+	c, _ = gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest("POST", "/api/admin/roles/requests/1/approve", nil)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Set(string(middleware.CurrentUserKey), &User{Email: "Peter@school.edu", Role: "admin"})
+	h.ApproveRoleRequest(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for the staff member approving their own elevation, got %d: %s", w.Code, w.Body)
+	}
+	if role, _ := users.FindRole(context.Background(), "peter@school.edu"); role != "admin" {
+		t.Errorf("Expected peter to stay admin, got %q", role)
+	}
+}
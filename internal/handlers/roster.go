@@ -0,0 +1,421 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"elimu-go/internal/pdf"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RosterHandler lets instructors and registrars see and manage who is in
+// an offering.
+type RosterHandler struct {
+	roster repository.RosterRepository
+	terms  repository.TermRepository
+}
+
+func NewRosterHandler(roster repository.RosterRepository, terms repository.TermRepository) *RosterHandler {
+	return &RosterHandler{roster: roster, terms: terms}
+}
+
+// Roster is an offering with its enrolled and waitlisted students
+// swagger:model Roster
+type Roster struct {
+	Offering   repository.Offering      `json:"offering"`
+	Enrolled   []repository.RosterEntry `json:"enrolled"`
+	Waitlisted []repository.RosterEntry `json:"waitlisted"`
+}
+
+// RosterAddRequest adds a student to an offering by hand
+// swagger:model RosterAddRequest
+type RosterAddRequest struct {
+	Student string `json:"student" binding:"required,email"`
+	Reason  string `json:"reason" binding:"max=500"`
+}
+
+// RosterRemoveRequest is the optional body of a manual removal
+// swagger:model RosterRemoveRequest
+type RosterRemoveRequest struct {
+	Reason string `json:"reason" binding:"max=500"`
+}
+
+// authorize loads the offering and checks that the caller teaches it or
+// administers courses.
+func (h *RosterHandler) authorize(c *gin.Context, ctx context.Context, id int) (repository.Offering, bool) {
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return repository.Offering{}, false
+	}
+
+	offering, err := h.terms.GetOffering(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load offering")
+		return repository.Offering{}, false
+	}
+	if !slices.Contains(courseAdminRoles, user.Role) && !offering.IsInstructor(user.Email) {
+		problem.Abort(c, problem.NotInstructor, "")
+		return repository.Offering{}, false
+	}
+	return offering, true
+}
+
+// splitRoster separates enrolled students from the waitlist.
+func splitRoster(offering repository.Offering, entries []repository.RosterEntry) Roster {
+	r := Roster{Offering: offering, Enrolled: []repository.RosterEntry{}, Waitlisted: []repository.RosterEntry{}}
+	for _, e := range entries {
+		if e.Status == repository.Waitlisted {
+			r.Waitlisted = append(r.Waitlisted, e)
+		} else {
+			r.Enrolled = append(r.Enrolled, e)
+		}
+	}
+	return r
+}
+
+// GetRoster godoc
+// @Summary      Get an offering's roster
+// @Description  Lists the students enrolled in an offering by name and its waitlist in order. Only the offering's instructors and course administrators may see it.
+// @Tags         Roster
+// @Produce      json
+// @Param        id   path  int  true  "Offering ID"
+// @Success      200  {object}  Roster
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Not an instructor of this offering"
+// @Failure      404  {object}  problem.Problem  "Offering not found"
+// @Router       /offerings/{id}/roster [get]
+func (h *RosterHandler) GetRoster(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	offering, ok := h.authorize(c, ctx, id)
+	if !ok {
+		return
+	}
+	entries, err := h.roster.Roster(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load roster")
+		return
+	}
+	c.JSON(http.StatusOK, splitRoster(offering, entries))
+}
+
+// ExportRoster godoc
+// @Summary      Export an offering's roster
+// @Description  Downloads the roster as CSV, waitlist included, or as a printable PDF sign-in sheet of the enrolled students
+// @Tags         Roster
+// @Produce      text/csv
+// @Produce      application/pdf
+// @Param        id      path   int     true   "Offering ID"
+// @Param        format  query  string  false  "Output format (csv or pdf)"  default(csv)
+// @Success      200
+// @Failure      400  {object}  problem.Problem  "Invalid format"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Not an instructor of this offering"
+// @Failure      404  {object}  problem.Problem  "Offering not found"
+// @Router       /offerings/{id}/roster/export [get]
+func (h *RosterHandler) ExportRoster(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	format := c.DefaultQuery("format", "csv")
+	if format != "csv" && format != "pdf" {
+		problem.Invalid(c, problem.FieldError{Field: "format", Message: "must be csv or pdf"})
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	offering, ok := h.authorize(c, ctx, id)
+	if !ok {
+		return
+	}
+	entries, err := h.roster.Roster(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load roster")
+		return
+	}
+	term, err := h.terms.GetTerm(ctx, offering.TermID)
+	if err != nil {
+		writeError(c, err, "Failed to load term")
+		return
+	}
+
+	name := fmt.Sprintf("%s-%s-%s", term.Code, offering.CourseCode, offering.Section)
+	var write func(io.Writer) error
+	if format == "csv" {
+		c.Header("Content-Type", "text/csv; charset=utf-8")
+		write = func(w io.Writer) error { return writeRosterCSV(w, entries) }
+	} else {
+		c.Header("Content-Type", "application/pdf")
+		write = func(w io.Writer) error {
+			_, err := signInSheet(term, offering, entries).WriteTo(w)
+			return err
+		}
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="roster-%s.%s"`, name, format))
+	c.Status(http.StatusOK)
+
+	if err := write(c.Writer); err != nil {
+		// Headers are already sent, all we can do is stop the stream.
+		slog.ErrorContext(ctx, "roster export: aborted", "offering_id", id, "error", err)
+	}
+}
+
+// AddToRoster godoc
+// @Summary      Add a student to a roster
+// @Description  Enrolls a student by hand, bypassing enrollment windows and capacity. A waitlisted student is moved into the offering. The change is recorded with the reason.
+// @Tags         Roster
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int               true  "Offering ID"
+// @Param        body  body      RosterAddRequest  true  "Student"
+// @Success      201   {object}  repository.Enrollment
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Not an instructor of this offering"
+// @Failure      404   {object}  problem.Problem  "Offering or student not found"
+// @Failure      409   {object}  problem.Problem  "Already enrolled"
+// @Router       /offerings/{id}/roster [post]
+func (h *RosterHandler) AddToRoster(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body RosterAddRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if _, ok := h.authorize(c, ctx, id); !ok {
+		return
+	}
+	actor := currentUser(c).Email
+	enrollment, err := h.roster.AddStudent(ctx, id, strings.TrimSpace(body.Student), actor, strings.TrimSpace(body.Reason))
+	if err != nil {
+		writeError(c, err, "Failed to add student")
+		return
+	}
+	slog.InfoContext(ctx, "roster student added", "offering_id", id, "student", enrollment.StudentEmail, "actor", actor)
+	c.JSON(http.StatusCreated, enrollment)
+}
+
+// RemoveFromRoster godoc
+// @Summary      Remove a student from a roster
+// @Description  Drops an enrollment or waitlist place by hand. A freed seat goes to the next student on the waitlist. The change is recorded with the reason.
+// @Tags         Roster
+// @Accept       json
+// @Produce      json
+// @Param        id          path      int                  true   "Offering ID"
+// @Param        enrollment  path      int                  true   "Enrollment ID"
+// @Param        body        body      RosterRemoveRequest  false  "Reason"
+// @Success      200         {object}  repository.Enrollment  "The dropped enrollment"
+// @Failure      400         {object}  problem.Problem  "Invalid request"
+// @Failure      401         {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403         {object}  problem.Problem  "Not an instructor of this offering"
+// @Failure      404         {object}  problem.Problem  "Offering or enrollment not found"
+// @Router       /offerings/{id}/roster/{enrollment} [delete]
+func (h *RosterHandler) RemoveFromRoster(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	enrollmentID, err := strconv.Atoi(c.Param("enrollment"))
+	if err != nil {
+		problem.Invalid(c, problem.FieldError{Field: "enrollment", Message: "must be an integer"})
+		return
+	}
+	var body RosterRemoveRequest
+	if err := c.ShouldBindJSON(&body); err != nil && !errors.Is(err, io.EOF) {
+		problem.Invalid(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if _, ok := h.authorize(c, ctx, id); !ok {
+		return
+	}
+	actor := currentUser(c).Email
+	result, err := h.roster.RemoveStudent(ctx, id, enrollmentID, actor, strings.TrimSpace(body.Reason))
+	if err != nil {
+		writeError(c, err, "Failed to remove student")
+		return
+	}
+	slog.InfoContext(ctx, "roster student removed", "offering_id", id, "student", result.Dropped.StudentEmail,
+		"actor", actor, "promoted", len(result.Promoted))
+	c.JSON(http.StatusOK, result.Dropped)
+}
+
+// ListRosterChanges godoc
+// @Summary      List manual roster changes
+// @Description  Lists who added or removed students from the offering by hand, and why, newest first
+// @Tags         Roster
+// @Produce      json
+// @Param        id   path  int  true  "Offering ID"
+// @Success      200  {array}   repository.RosterChange
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Not an instructor of this offering"
+// @Failure      404  {object}  problem.Problem  "Offering not found"
+// @Router       /offerings/{id}/roster/changes [get]
+func (h *RosterHandler) ListRosterChanges(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if _, ok := h.authorize(c, ctx, id); !ok {
+		return
+	}
+	changes, err := h.roster.RosterChanges(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load roster changes")
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// ListTermRosterChanges godoc
+// @Summary      List a term's manual roster changes
+// @Description  Lists who added or removed students by hand in any offering of the term, deleted offerings included, newest first
+// @Tags         Roster
+// @Produce      json
+// @Param        id      path   int     true   "Term ID"
+// @Param        course  query  string  false  "Only changes to this course code"
+// @Success      200  {array}   repository.RosterChange
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404  {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id}/roster-changes [get]
+func (h *RosterHandler) ListTermRosterChanges(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	course := strings.ToUpper(strings.TrimSpace(c.Query("course")))
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	changes, err := h.roster.TermRosterChanges(ctx, id, course)
+	if err != nil {
+		writeError(c, err, "Failed to load roster changes")
+		return
+	}
+	c.JSON(http.StatusOK, changes)
+}
+
+// rosterColumns are the columns of a CSV roster export.
+var rosterColumns = []string{"last_name", "first_name", "email", "cohort", "status", "waitlist_position", "enrolled_at"}
+
+func writeRosterCSV(w io.Writer, entries []repository.RosterEntry) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(rosterColumns); err != nil {
+		return err
+	}
+	for _, e := range entries {
+		record := []string{e.LastName, e.FirstName, e.Email, "", e.Status, "", ""}
+		if e.Cohort != nil {
+			record[3] = *e.Cohort
+		}
+		if e.WaitlistPosition > 0 {
+			record[5] = strconv.Itoa(e.WaitlistPosition)
+		}
+		if e.EnrolledAt != nil {
+			record[6] = e.EnrolledAt.UTC().Format(time.RFC3339)
+		}
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// Sign-in sheet layout, in points from the top of the page.
+const (
+	sheetMargin    = 40
+	sheetRowHeight = 26
+	sheetTableTop  = 190
+	sheetFooter    = pdf.PageHeight - sheetMargin
+	sheetBottom    = sheetFooter - 20
+)
+
+// signInSheet lays out the enrolled students of an offering with a blank
+// signature line each, continuing over as many pages as needed.
+func signInSheet(term repository.Term, offering repository.Offering, entries []repository.RosterEntry) *pdf.Document {
+	title := fmt.Sprintf("%s %s section %s", term.Code, offering.CourseCode, offering.Section)
+	doc := pdf.New("Sign-in sheet: " + title)
+
+	var enrolled []repository.RosterEntry
+	for _, e := range entries {
+		if e.Status == repository.Enrolled {
+			enrolled = append(enrolled, e)
+		}
+	}
+	instructors := make([]string, len(offering.Instructors))
+	for i, in := range offering.Instructors {
+		instructors[i] = strings.TrimSpace(in.FirstName + " " + in.LastName)
+	}
+
+	right := pdf.PageWidth - sheetMargin
+	newPage := func() {
+		doc.AddPage()
+		doc.Text(sheetMargin, 60, 18, true, "Sign-in sheet")
+		doc.Text(sheetMargin, 85, 12, false, pdf.Fit(fmt.Sprintf("%s %s, section %s", offering.CourseCode, offering.CourseTitle, offering.Section), 80))
+		doc.Text(sheetMargin, 103, 10, false, pdf.Fit(fmt.Sprintf("%s (%s)", term.Name, term.Code), 90))
+		doc.Text(sheetMargin, 121, 10, false, pdf.Fit("Instructors: "+strings.Join(instructors, ", "), 90))
+		doc.Text(sheetMargin, 145, 10, false, "Date: ____________________")
+		doc.Text(right-100, 145, 10, false, fmt.Sprintf("Enrolled: %d", len(enrolled)))
+		doc.Text(sheetMargin, sheetFooter, 8, false, fmt.Sprintf("%s - page %d", title, doc.Pages()))
+
+		doc.Text(sheetMargin, sheetTableTop-8, 10, true, "#")
+		doc.Text(sheetMargin+25, sheetTableTop-8, 10, true, "Name")
+		doc.Text(sheetMargin+215, sheetTableTop-8, 10, true, "Email")
+		doc.Text(sheetMargin+400, sheetTableTop-8, 10, true, "Signature")
+		doc.Line(sheetMargin, sheetTableTop, right, sheetTableTop)
+	}
+
+	newPage()
+	y := float64(sheetTableTop)
+	for i, e := range enrolled {
+		if y+sheetRowHeight > sheetBottom {
+			newPage()
+			y = sheetTableTop
+		}
+		y += sheetRowHeight
+		doc.Text(sheetMargin, y-8, 10, false, strconv.Itoa(i+1))
+		doc.Text(sheetMargin+25, y-8, 10, false, pdf.Fit(e.LastName+", "+e.FirstName, 34))
+		doc.Text(sheetMargin+215, y-8, 9, false, pdf.Fit(e.Email, 36))
+		doc.Line(sheetMargin, y, right, y)
+	}
+	return doc
+}
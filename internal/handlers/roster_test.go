@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"elimu-go/internal/middleware"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestWriteRosterCSV(t *testing.T) {
+	cohort := "2027"
+	enrolled := time.Date(2026, 9, 1, 8, 30, 0, 0, time.UTC)
+	entries := []repository.RosterEntry{
+		{FirstName: "Amani", LastName: "Wanjiru", Email: "amani@student.school.edu", Cohort: &cohort, Status: repository.Enrolled, EnrolledAt: &enrolled},
+		{FirstName: "Baraka", LastName: "Otieno, Jr", Email: "baraka@student.school.edu", Status: repository.Waitlisted, WaitlistPosition: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := writeRosterCSV(&buf, entries); err != nil {
+		t.Fatalf("writeRosterCSV: %v", err)
+	}
+
+	want := "last_name,first_name,email,cohort,status,waitlist_position,enrolled_at\n" +
+		"Wanjiru,Amani,amani@student.school.edu,2027,enrolled,,2026-09-01T08:30:00Z\n" +
+		"\"Otieno, Jr\",Baraka,baraka@student.school.edu,,waitlisted,1,\n"
+	if buf.String() != want {
+		t.Errorf("Unexpected CSV:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestSignInSheet_Paginates(t *testing.T) {
+	term := repository.Term{Code: "2026-S2", Name: "Semester 2"}
+	offering := repository.Offering{CourseCode: "CS101", CourseTitle: "Intro to Programming", Section: "A"}
+
+	var entries []repository.RosterEntry
+	for i := range 40 {
+		entries = append(entries, repository.RosterEntry{
+			FirstName: "Student", LastName: fmt.Sprint(i), Email: fmt.Sprintf("s%d@student.school.edu", i), Status: repository.Enrolled,
+		})
+	}
+	// Waitlisted students do not sign in.
+	entries = append(entries, repository.RosterEntry{FirstName: "Late", LastName: "Comer", Status: repository.Waitlisted})
+
+	doc := signInSheet(term, offering, entries)
+	if doc.Pages() != 2 {
+		t.Errorf("Expected 40 students to take two pages, got %d", doc.Pages())
+	}
+
+	var buf bytes.Buffer
+	if _, err := doc.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	if strings.Contains(buf.String(), "Comer") {
+		t.Error("Expected waitlisted students to be left off the sheet")
+	}
+	if !strings.Contains(buf.String(), "(Enrolled: 40)") {
+		t.Error("Expected the enrolled count in the header")
+	}
+}
+
+func TestExportRoster_InvalidFormat(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewRosterHandler(nil, nil)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	c.Request = httptest.NewRequest("GET", "/api/offerings/1/roster/export?format=xlsx", nil)
+
+	h.ExportRoster(c)
+
+	if w.Code != http.StatusBadRequest {
+		t.Errorf("Expected 400, got %d", w.Code)
+	}
+}
+
+func TestGetRoster_InstructorsOnly(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctx := context.Background()
+	users := repository.NewMemoryUserRepository()
+	users.AddStaff(repository.StaffRow{FirstName: "Ruth", LastName: "Njeri", Email: "ruth@school.edu", Role: "teacher"})
+	courses := repository.NewMemoryCourseRepository(users)
+	terms := repository.NewMemoryTermRepository(courses)
+	course, err := courses.CreateCourse(ctx, repository.Course{Code: "CS101", Title: "Intro", Credits: 3, Department: "CS"}, nil)
+	if err != nil {
+		t.Fatalf("CreateCourse: %v", err)
+	}
+	term, err := terms.CreateTerm(ctx, repository.Term{Code: "2026-S2", Name: "Semester 2"})
+	if err != nil {
+		t.Fatalf("CreateTerm: %v", err)
+	}
+	offering, err := terms.CreateOffering(ctx, repository.Offering{
+		TermID: term.ID, CourseID: course.ID, Section: "A", Capacity: 30,
+	}, []string{"ruth@school.edu"})
+	if err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+
+	// A non-instructor is turned away before the roster is read, so no
+	// roster repository is needed.
+	h := NewRosterHandler(nil, terms)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Params = gin.Params{{Key: "id", Value: fmt.Sprint(offering.ID)}}
+	c.Request = httptest.NewRequest("GET", "/api/offerings/1/roster", nil)
+	c.Set(string(middleware.CurrentUserKey), &User{Email: "peter@school.edu", Role: "teacher"})
+
+	h.GetRoster(c)
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a teacher who does not teach the offering, got %d: %s", w.Code, w.Body.String())
+	}
+}
@@ -0,0 +1,477 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	dateLayout      = "2006-01-02"
+	timeOfDayLayout = "15:04"
+)
+
+// TermHandler serves terms, the course offerings in them and when students
+// may enroll.
+type TermHandler struct {
+	terms   repository.TermRepository
+	windows repository.WindowRepository
+}
+
+func NewTermHandler(terms repository.TermRepository, windows repository.WindowRepository) *TermHandler {
+	return &TermHandler{terms: terms, windows: windows}
+}
+
+// TermRequest is the body used to create or update a term
+// swagger:model TermRequest
+type TermRequest struct {
+	// Short unique code, e.g. 2026-S1
+	Code string `json:"code" binding:"required,max=20"`
+	Name string `json:"name" binding:"required,max=100"`
+
+	// First and last day of teaching, YYYY-MM-DD
+	StartsOn string `json:"starts_on" binding:"required,datetime=2006-01-02"`
+	EndsOn   string `json:"ends_on" binding:"required,datetime=2006-01-02"`
+
+	EnrollmentOpensAt  time.Time `json:"enrollment_opens_at" binding:"required"`
+	EnrollmentClosesAt time.Time `json:"enrollment_closes_at" binding:"required"`
+	AddDropDeadline    time.Time `json:"add_drop_deadline" binding:"required"`
+}
+
+// term validates the request and converts it for the repository.
+func (r TermRequest) term() (repository.Term, error) {
+	t := repository.Term{
+		Code:               strings.ToUpper(strings.TrimSpace(r.Code)),
+		Name:               strings.TrimSpace(r.Name),
+		EnrollmentOpensAt:  r.EnrollmentOpensAt,
+		EnrollmentClosesAt: r.EnrollmentClosesAt,
+		AddDropDeadline:    r.AddDropDeadline,
+	}
+	// Both dates passed binding, so they parse.
+	t.StartsOn, _ = time.Parse(dateLayout, r.StartsOn)
+	t.EndsOn, _ = time.Parse(dateLayout, r.EndsOn)
+
+	var errs []error
+	if !t.EndsOn.After(t.StartsOn) {
+		errs = append(errs, problem.FieldError{Field: "ends_on", Message: "must be after starts_on"})
+	}
+	if !t.EnrollmentClosesAt.After(t.EnrollmentOpensAt) {
+		errs = append(errs, problem.FieldError{Field: "enrollment_closes_at", Message: "must be after enrollment_opens_at"})
+	}
+	if t.AddDropDeadline.Before(t.EnrollmentOpensAt) || t.AddDropDeadline.After(t.EndsOn.AddDate(0, 0, 1)) {
+		errs = append(errs, problem.FieldError{Field: "add_drop_deadline", Message: "must fall between enrollment_opens_at and ends_on"})
+	}
+	return t, errors.Join(errs...)
+}
+
+// OfferingRequest is the body used to update an offering
+// swagger:model OfferingRequest
+type OfferingRequest struct {
+	Section  string `json:"section" binding:"required,max=10"`
+	Capacity *int   `json:"capacity" binding:"required,min=0"`
+
+	// Staff emails teaching the offering. On update an absent list keeps
+	// the current instructors.
+	Instructors []string         `json:"instructors" binding:"omitempty,dive,email"`
+	Meetings    []MeetingRequest `json:"meetings" binding:"omitempty,dive"`
+}
+
+// CreateOfferingRequest is the body used to schedule a course in a term
+// swagger:model CreateOfferingRequest
+type CreateOfferingRequest struct {
+	CourseID int `json:"course_id" binding:"required,min=1"`
+	OfferingRequest
+}
+
+// MeetingRequest is a weekly class meeting
+// swagger:model MeetingRequest
+type MeetingRequest struct {
+	// ISO weekday: 1 is Monday, 7 is Sunday
+	Weekday int `json:"weekday" binding:"required,min=1,max=7"`
+
+	// Wall-clock times, HH:MM
+	StartsAt string `json:"starts_at" binding:"required,datetime=15:04"`
+	EndsAt   string `json:"ends_at" binding:"required,datetime=15:04"`
+	Room     string `json:"room" binding:"max=50"`
+}
+
+// offering validates the request and converts it for the repository.
+func (r OfferingRequest) offering() (repository.Offering, error) {
+	o := repository.Offering{
+		Section:  strings.ToUpper(strings.TrimSpace(r.Section)),
+		Capacity: *r.Capacity,
+		Meetings: make([]repository.Meeting, len(r.Meetings)),
+	}
+
+	var errs []error
+	for i, m := range r.Meetings {
+		// Both times passed binding, so they parse.
+		start, _ := time.Parse(timeOfDayLayout, m.StartsAt)
+		end, _ := time.Parse(timeOfDayLayout, m.EndsAt)
+		if !end.After(start) {
+			errs = append(errs, problem.FieldError{Field: fmt.Sprintf("meetings[%d].ends_at", i), Message: "must be after starts_at"})
+		}
+		o.Meetings[i] = repository.Meeting{
+			Weekday:  m.Weekday,
+			StartsAt: start.Format(timeOfDayLayout),
+			EndsAt:   end.Format(timeOfDayLayout),
+			Room:     strings.TrimSpace(m.Room),
+		}
+	}
+	for i := range o.Meetings {
+		for j := range i {
+			if o.Meetings[i].Overlaps(o.Meetings[j]) {
+				errs = append(errs, problem.FieldError{Field: fmt.Sprintf("meetings[%d]", i), Message: fmt.Sprintf("overlaps meetings[%d]", j)})
+			}
+		}
+	}
+	return o, errors.Join(errs...)
+}
+
+// CloneOfferingsRequest names the term to copy offerings from
+// swagger:model CloneOfferingsRequest
+type CloneOfferingsRequest struct {
+	FromTermID int `json:"from_term_id" binding:"required,min=1"`
+}
+
+// ListTerms godoc
+// @Summary      List terms
+// @Description  Lists every term, latest first
+// @Tags         Terms
+// @Produce      json
+// @Success      200  {array}   repository.Term
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Router       /terms [get]
+func (h *TermHandler) ListTerms(c *gin.Context) {
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	terms, err := h.terms.ListTerms(ctx)
+	if err != nil {
+		writeError(c, err, "Failed to load terms")
+		return
+	}
+	c.JSON(http.StatusOK, terms)
+}
+
+// GetTerm godoc
+// @Summary      Get a term
+// @Tags         Terms
+// @Produce      json
+// @Param        id   path  int  true  "Term ID"
+// @Success      200  {object}  repository.Term
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id} [get]
+func (h *TermHandler) GetTerm(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	term, err := h.terms.GetTerm(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load term")
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+// CreateTerm godoc
+// @Summary      Create a term
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        body  body      TermRequest  true  "Term"
+// @Success      201   {object}  repository.Term
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      409   {object}  problem.Problem  "Term code already in use"
+// @Router       /terms [post]
+func (h *TermHandler) CreateTerm(c *gin.Context) {
+	var body TermRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	term, err := body.term()
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	term, err = h.terms.CreateTerm(ctx, term)
+	if err != nil {
+		writeError(c, err, "Failed to create term")
+		return
+	}
+	c.JSON(http.StatusCreated, term)
+}
+
+// UpdateTerm godoc
+// @Summary      Update a term
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int          true  "Term ID"
+// @Param        body  body      TermRequest  true  "Term"
+// @Success      200   {object}  repository.Term
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Term not found"
+// @Failure      409   {object}  problem.Problem  "Term code already in use"
+// @Router       /terms/{id} [put]
+func (h *TermHandler) UpdateTerm(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body TermRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	term, err := body.term()
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	term.ID = id
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	term, err = h.terms.UpdateTerm(ctx, term)
+	if err != nil {
+		writeError(c, err, "Failed to update term")
+		return
+	}
+	c.JSON(http.StatusOK, term)
+}
+
+// ListOfferings godoc
+// @Summary      List a term's offerings
+// @Description  Lists the course sections scheduled in a term, ordered by course code and section
+// @Tags         Terms
+// @Produce      json
+// @Param        id   path  int  true  "Term ID"
+// @Success      200  {array}   repository.Offering
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id}/offerings [get]
+func (h *TermHandler) ListOfferings(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	offerings, err := h.terms.ListOfferings(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load offerings")
+		return
+	}
+	c.JSON(http.StatusOK, offerings)
+}
+
+// CreateOffering godoc
+// @Summary      Schedule a course
+// @Description  Adds a section of a course to a term, with its instructors and weekly meetings
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Term ID"
+// @Param        body  body      CreateOfferingRequest  true  "Offering"
+// @Success      201   {object}  repository.Offering
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Term, course or instructor not found"
+// @Failure      409   {object}  problem.Problem  "Section already exists in the term, or a room is already booked"
+// @Router       /terms/{id}/offerings [post]
+func (h *TermHandler) CreateOffering(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body CreateOfferingRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	offering, err := body.offering()
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	offering.TermID = termID
+	offering.CourseID = body.CourseID
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	instructors := body.Instructors
+	if instructors == nil {
+		instructors = []string{}
+	}
+	offering, err = h.terms.CreateOffering(ctx, offering, instructors)
+	if err != nil {
+		writeError(c, err, "Failed to create offering")
+		return
+	}
+	c.JSON(http.StatusCreated, offering)
+}
+
+// CloneOfferings godoc
+// @Summary      Clone offerings from another term
+// @Description  Copies every offering of a previous term, with instructors and meetings, into this one. Sections that already exist here are skipped. Offerings needing a room that is already booked at the same time here are left out and listed in clashes.
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                    true  "Target term ID"
+// @Param        body  body      CloneOfferingsRequest  true  "Source term"
+// @Success      200   {object}  repository.CloneResult
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id}/offerings/clone [post]
+func (h *TermHandler) CloneOfferings(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body CloneOfferingsRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	if body.FromTermID == termID {
+		problem.Invalid(c, problem.FieldError{Field: "from_term_id", Message: "must differ from the target term"})
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	result, err := h.terms.CloneOfferings(ctx, body.FromTermID, termID)
+	if err != nil {
+		writeError(c, err, "Failed to clone offerings")
+		return
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+// GetOffering godoc
+// @Summary      Get an offering
+// @Tags         Terms
+// @Produce      json
+// @Param        id   path  int  true  "Offering ID"
+// @Success      200  {object}  repository.Offering
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Offering not found"
+// @Router       /offerings/{id} [get]
+func (h *TermHandler) GetOffering(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	offering, err := h.terms.GetOffering(ctx, id)
+	if err != nil {
+		writeError(c, err, "Failed to load offering")
+		return
+	}
+	c.JSON(http.StatusOK, offering)
+}
+
+// UpdateOffering godoc
+// @Summary      Update an offering
+// @Description  Replaces an offering's section, capacity and meetings, and its instructors when listed
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int              true  "Offering ID"
+// @Param        body  body      OfferingRequest  true  "Offering"
+// @Success      200   {object}  repository.Offering
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Offering or instructor not found"
+// @Failure      409   {object}  problem.Problem  "Section already exists in the term, or a room is already booked"
+// @Router       /offerings/{id} [put]
+func (h *TermHandler) UpdateOffering(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body OfferingRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	offering, err := body.offering()
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	offering.ID = id
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	offering, err = h.terms.UpdateOffering(ctx, offering, body.Instructors)
+	if err != nil {
+		writeError(c, err, "Failed to update offering")
+		return
+	}
+	c.JSON(http.StatusOK, offering)
+}
+
+// DeleteOffering godoc
+// @Summary      Delete an offering
+// @Description  Removes an offering. Offerings with enrolled or waitlisted students cannot be deleted; remove them from the roster first.
+// @Tags         Terms
+// @Produce      json
+// @Param        id   path  int  true  "Offering ID"
+// @Success      200  {object}  map[string]interface{}
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404  {object}  problem.Problem  "Offering not found"
+// @Failure      409  {object}  problem.Problem  "Students are still enrolled or waitlisted"
+// @Router       /offerings/{id} [delete]
+func (h *TermHandler) DeleteOffering(c *gin.Context) {
+	id, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	if err := h.terms.DeleteOffering(ctx, id); err != nil {
+		writeError(c, err, "Failed to delete offering")
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Offering deleted"})
+}
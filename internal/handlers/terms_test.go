@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestCreateTerm_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewTermHandler(nil, nil)
+
+	const window = `"enrollment_opens_at": "2026-08-01T00:00:00Z", "enrollment_closes_at": "2026-09-10T00:00:00Z"`
+	for _, body := range []string{
+		`{"code": "2026-S1", "name": "Semester 1"}`,
+		`{"code": "2026-S1", "name": "Semester 1", "starts_on": "01/09/2026", "ends_on": "2026-12-15", ` + window + `, "add_drop_deadline": "2026-09-15T00:00:00Z"}`,
+		`{"code": "2026-S1", "name": "Semester 1", "starts_on": "2026-12-15", "ends_on": "2026-09-01", ` + window + `, "add_drop_deadline": "2026-09-15T00:00:00Z"}`,
+		`{"code": "2026-S1", "name": "Semester 1", "starts_on": "2026-09-01", "ends_on": "2026-12-15", ` + window + `, "add_drop_deadline": "2027-01-15T00:00:00Z"}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("POST", "/api/terms", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.CreateTerm(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestCreateOffering_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewTermHandler(nil, nil)
+
+	for _, body := range []string{
+		`{"section": "A", "capacity": 30}`,
+		`{"course_id": 1, "section": "A"}`,
+		`{"course_id": 1, "section": "A", "capacity": 30, "meetings": [{"weekday": 8, "starts_at": "09:00", "ends_at": "10:00"}]}`,
+		`{"course_id": 1, "section": "A", "capacity": 30, "meetings": [{"weekday": 1, "starts_at": "9am", "ends_at": "10:00"}]}`,
+		`{"course_id": 1, "section": "A", "capacity": 30, "meetings": [{"weekday": 1, "starts_at": "11:00", "ends_at": "10:00"}]}`,
+		`{"course_id": 1, "section": "A", "capacity": 30, "meetings": [{"weekday": 1, "starts_at": "09:00", "ends_at": "10:30"}, {"weekday": 1, "starts_at": "10:00", "ends_at": "11:00"}]}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+		c.Request = httptest.NewRequest("POST", "/api/terms/1/offerings", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.CreateOffering(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
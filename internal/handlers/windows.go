@@ -0,0 +1,314 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EnrollmentWindowsRequest replaces a term's cohort enrollment windows
+// swagger:model EnrollmentWindowsRequest
+type EnrollmentWindowsRequest struct {
+	// An empty list removes every cohort window, leaving the term's own
+	Windows []EnrollmentWindowRequest `json:"windows" binding:"omitempty,dive"`
+}
+
+// EnrollmentWindowRequest is when one cohort may enroll
+// swagger:model EnrollmentWindowRequest
+type EnrollmentWindowRequest struct {
+	// Cohort or priority group, e.g. a graduation year
+	Cohort   string    `json:"cohort" binding:"required,max=20"`
+	OpensAt  time.Time `json:"opens_at" binding:"required"`
+	ClosesAt time.Time `json:"closes_at" binding:"required"`
+}
+
+// windows validates the request and converts it for the repository.
+func (r EnrollmentWindowsRequest) windows() ([]repository.EnrollmentWindow, error) {
+	windows := make([]repository.EnrollmentWindow, len(r.Windows))
+	seen := make(map[string]bool, len(r.Windows))
+
+	var errs []error
+	for i, w := range r.Windows {
+		cohort := strings.TrimSpace(w.Cohort)
+		if seen[cohort] {
+			errs = append(errs, problem.FieldError{Field: fmt.Sprintf("windows[%d].cohort", i), Message: "is listed twice"})
+		}
+		seen[cohort] = true
+		if !w.ClosesAt.After(w.OpensAt) {
+			errs = append(errs, problem.FieldError{Field: fmt.Sprintf("windows[%d].closes_at", i), Message: "must be after opens_at"})
+		}
+		windows[i] = repository.EnrollmentWindow{Cohort: cohort, OpensAt: w.OpensAt, ClosesAt: w.ClosesAt}
+	}
+	return windows, errors.Join(errs...)
+}
+
+// EnrollmentOverrideRequest lets a student enroll outside their window
+// swagger:model EnrollmentOverrideRequest
+type EnrollmentOverrideRequest struct {
+	Student string `json:"student" binding:"required,email"`
+	Reason  string `json:"reason" binding:"required,max=500"`
+
+	// Optional end of the override; it lasts until revoked otherwise
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// StudentCohortRequest assigns a student to a cohort
+// swagger:model StudentCohortRequest
+type StudentCohortRequest struct {
+	Student string `json:"student" binding:"required,email"`
+
+	// Cohort or priority group, e.g. a graduation year; empty removes the
+	// student from their cohort
+	Cohort string `json:"cohort" binding:"max=20"`
+}
+
+// ListEnrollmentWindows godoc
+// @Summary      List a term's enrollment windows
+// @Description  Lists the per-cohort enrollment windows of a term. Students whose cohort has no window enroll during the term's own window.
+// @Tags         Terms
+// @Produce      json
+// @Param        id   path  int  true  "Term ID"
+// @Success      200  {array}   repository.EnrollmentWindow
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      404  {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id}/windows [get]
+func (h *TermHandler) ListEnrollmentWindows(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	windows, err := h.windows.EnrollmentWindows(ctx, termID)
+	if err != nil {
+		writeError(c, err, "Failed to load enrollment windows")
+		return
+	}
+	c.JSON(http.StatusOK, windows)
+}
+
+// SetEnrollmentWindows godoc
+// @Summary      Set a term's enrollment windows
+// @Description  Replaces the per-cohort enrollment windows of a term, e.g. to let seniors register first
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                       true  "Term ID"
+// @Param        body  body      EnrollmentWindowsRequest  true  "Windows"
+// @Success      200   {array}   repository.EnrollmentWindow
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id}/windows [put]
+func (h *TermHandler) SetEnrollmentWindows(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body EnrollmentWindowsRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	windows, err := body.windows()
+	if err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	saved, err := h.windows.SetEnrollmentWindows(ctx, termID, windows)
+	if err != nil {
+		writeError(c, err, "Failed to save enrollment windows")
+		return
+	}
+	c.JSON(http.StatusOK, saved)
+}
+
+// ListEnrollmentOverrides godoc
+// @Summary      List enrollment overrides
+// @Description  Lists the term's late-registration overrides, revoked ones included, newest first
+// @Tags         Terms
+// @Produce      json
+// @Param        id   path  int  true  "Term ID"
+// @Success      200  {array}   repository.EnrollmentOverride
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404  {object}  problem.Problem  "Term not found"
+// @Router       /terms/{id}/overrides [get]
+func (h *TermHandler) ListEnrollmentOverrides(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	overrides, err := h.windows.ListOverrides(ctx, termID)
+	if err != nil {
+		writeError(c, err, "Failed to load enrollment overrides")
+		return
+	}
+	c.JSON(http.StatusOK, overrides)
+}
+
+// GrantEnrollmentOverride godoc
+// @Summary      Grant an enrollment override
+// @Description  Lets a student enroll in the term outside their enrollment window. The reason and the granting admin are recorded; a new override replaces the student's previous one.
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        id    path      int                        true  "Term ID"
+// @Param        body  body      EnrollmentOverrideRequest  true  "Override"
+// @Success      201   {object}  repository.EnrollmentOverride
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Term or student not found"
+// @Router       /terms/{id}/overrides [post]
+func (h *TermHandler) GrantEnrollmentOverride(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	var body EnrollmentOverrideRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	reason := strings.TrimSpace(body.Reason)
+	if reason == "" {
+		problem.Invalid(c, problem.FieldError{Field: "reason", Message: "is required"})
+		return
+	}
+	if body.ExpiresAt != nil && !body.ExpiresAt.After(time.Now()) {
+		problem.Invalid(c, problem.FieldError{Field: "expires_at", Message: "must be in the future"})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	override, err := h.windows.GrantOverride(ctx, repository.EnrollmentOverride{
+		TermID:       termID,
+		StudentEmail: strings.TrimSpace(body.Student),
+		Reason:       reason,
+		GrantedBy:    user.Email,
+		ExpiresAt:    body.ExpiresAt,
+	})
+	if err != nil {
+		writeError(c, err, "Failed to grant enrollment override")
+		return
+	}
+	slog.InfoContext(ctx, "enrollment override granted",
+		"term_id", termID, "student", override.StudentEmail, "granted_by", user.Email)
+	c.JSON(http.StatusCreated, override)
+}
+
+// RevokeEnrollmentOverride godoc
+// @Summary      Revoke an enrollment override
+// @Description  Ends an override. It stays listed with who revoked it.
+// @Tags         Terms
+// @Produce      json
+// @Param        id        path  int  true  "Term ID"
+// @Param        override  path  int  true  "Override ID"
+// @Success      200  {object}  repository.EnrollmentOverride
+// @Failure      400  {object}  problem.Problem  "Invalid request"
+// @Failure      401  {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403  {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404  {object}  problem.Problem  "Override not found or already revoked"
+// @Router       /terms/{id}/overrides/{override} [delete]
+func (h *TermHandler) RevokeEnrollmentOverride(c *gin.Context) {
+	termID, ok := pathID(c)
+	if !ok {
+		return
+	}
+	id, err := strconv.Atoi(c.Param("override"))
+	if err != nil {
+		problem.Invalid(c, problem.FieldError{Field: "override", Message: "must be an integer"})
+		return
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	override, err := h.windows.RevokeOverride(ctx, termID, id, user.Email)
+	if err != nil {
+		writeError(c, err, "Failed to revoke enrollment override")
+		return
+	}
+	slog.InfoContext(ctx, "enrollment override revoked",
+		"term_id", termID, "student", override.StudentEmail, "revoked_by", user.Email)
+	c.JSON(http.StatusOK, override)
+}
+
+// SetStudentCohort godoc
+// @Summary      Assign a student's cohort
+// @Description  Sets the cohort whose enrollment window the student uses. An empty cohort makes the student use each term's own window.
+// @Tags         Terms
+// @Accept       json
+// @Produce      json
+// @Param        body  body      StudentCohortRequest  true  "Cohort"
+// @Success      200   {object}  repository.StudentCohort
+// @Failure      400   {object}  problem.Problem  "Invalid request"
+// @Failure      401   {object}  problem.Problem  "Not logged in or session expired"
+// @Failure      403   {object}  problem.Problem  "Insufficient permissions"
+// @Failure      404   {object}  problem.Problem  "Student not found"
+// @Router       /students/cohort [put]
+func (h *TermHandler) SetStudentCohort(c *gin.Context) {
+	var body StudentCohortRequest
+	if err := c.ShouldBindJSON(&body); err != nil {
+		problem.Invalid(c, err)
+		return
+	}
+	name := strings.TrimSpace(body.Cohort)
+	var cohort *string
+	if name != "" {
+		cohort = &name
+	}
+
+	user := currentUser(c)
+	if user == nil {
+		problem.Abort(c, problem.NotLoggedIn, "")
+		return
+	}
+
+	ctx, cancel := requestContext(c)
+	defer cancel()
+
+	assigned, err := h.windows.SetStudentCohort(ctx, strings.TrimSpace(body.Student), cohort)
+	if err != nil {
+		writeError(c, err, "Failed to assign cohort")
+		return
+	}
+	slog.InfoContext(ctx, "student cohort assigned",
+		"student", assigned.StudentEmail, "cohort", name, "assigned_by", user.Email)
+	c.JSON(http.StatusOK, assigned)
+}
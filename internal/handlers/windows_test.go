@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestSetEnrollmentWindows_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewTermHandler(nil, nil)
+
+	const week = `"opens_at": "2026-11-02T08:00:00Z", "closes_at": "2026-11-09T08:00:00Z"`
+	for _, body := range []string{
+		`{"windows": [{"opens_at": "2026-11-02T08:00:00Z", "closes_at": "2026-11-09T08:00:00Z"}]}`,
+		`{"windows": [{"cohort": "2027", "opens_at": "2026-11-09T08:00:00Z", "closes_at": "2026-11-02T08:00:00Z"}]}`,
+		`{"windows": [{"cohort": "2027", ` + week + `}, {"cohort": "2027", ` + week + `}]}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+		c.Request = httptest.NewRequest("PUT", "/api/terms/1/windows", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.SetEnrollmentWindows(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestGrantEnrollmentOverride_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewTermHandler(nil, nil)
+
+	for _, body := range []string{
+		`{"student": "amani@student.school.edu"}`,
+		`{"student": "amani@student.school.edu", "reason": "   "}`,
+		`{"student": "not-an-email", "reason": "Late transfer"}`,
+		`{"student": "amani@student.school.edu", "reason": "Late transfer", "expires_at": "2020-01-01T00:00:00Z"}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "id", Value: "1"}}
+		c.Request = httptest.NewRequest("POST", "/api/terms/1/overrides", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.GrantEnrollmentOverride(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
+
+func TestSetStudentCohort_InvalidBody(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	h := NewTermHandler(nil, nil)
+
+	for _, body := range []string{
+		`{"cohort": "2027"}`,
+		`{"student": "not-an-email", "cohort": "2027"}`,
+		`{"student": "amani@student.school.edu", "cohort": "` + strings.Repeat("x", 21) + `"}`,
+	} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest("PUT", "/api/students/cohort", strings.NewReader(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+
+		h.SetStudentCohort(c)
+
+		if w.Code != http.StatusBadRequest {
+			t.Errorf("Expected 400 for %s, got %d", body, w.Code)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"time"
+
+	"elimu-go/database"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Postgres pings the database.
+func Postgres(pool *pgxpool.Pool) Check {
+	return Check{
+		Name:     "postgres",
+		Critical: true,
+		Run:      pool.Ping,
+	}
+}
+
+// PoolSaturation fails when more than max (0-1) of the pool's connections
+// are in use, an early sign of slow queries or leaked connections.
+func PoolSaturation(pool *pgxpool.Pool, max float64) Check {
+	return Check{
+		Name: "postgres_pool",
+		Run: func(context.Context) error {
+			stat := pool.Stat()
+			if stat.MaxConns() == 0 {
+				return nil
+			}
+			used := float64(stat.AcquiredConns()) / float64(stat.MaxConns())
+			if used > max {
+				return fmt.Errorf("%d of %d connections in use", stat.AcquiredConns(), stat.MaxConns())
+			}
+			return nil
+		},
+	}
+}
+
+// MigrationVersion fails when the database schema is behind the
+// migrations embedded in this build.
+func MigrationVersion(pool *pgxpool.Pool) Check {
+	return Check{
+		Name:     "migrations",
+		Critical: true,
+		Run: func(ctx context.Context) error {
+			want, err := database.LatestVersion()
+			if err != nil {
+				return err
+			}
+			got, err := database.AppliedVersion(ctx, pool)
+			if err != nil {
+				return err
+			}
+			if got < want {
+				return fmt.Errorf("schema at version %d, build expects %d", got, want)
+			}
+			return nil
+		},
+	}
+}
+
+// Heartbeat records when a background worker last completed a cycle.
+type Heartbeat struct {
+	last atomic.Int64
+}
+
+// Beat marks the worker as alive now.
+func (h *Heartbeat) Beat() {
+	if h != nil {
+		h.last.Store(time.Now().UnixNano())
+	}
+}
+
+// Check fails when the worker has not beaten within maxAge.
+func (h *Heartbeat) Check(name string, maxAge time.Duration) Check {
+	return Check{
+		Name: name,
+		Run: func(context.Context) error {
+			last := h.last.Load()
+			if last == 0 {
+				return errors.New("worker has not run yet")
+			}
+			if age := time.Since(time.Unix(0, last)); age > maxAge {
+				return fmt.Errorf("last heartbeat %s ago", age.Round(time.Second))
+			}
+			return nil
+		},
+	}
+}
@@ -0,0 +1,123 @@
+// Package health runs dependency checks for the liveness and readiness
+// endpoints.
+package health
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// DefaultTimeout bounds a check that does not set its own timeout.
+const DefaultTimeout = 2 * time.Second
+
+// Check statuses. A failing non-critical check degrades the service but
+// keeps it ready.
+const (
+	StatusUp       = "up"
+	StatusDown     = "down"
+	StatusDegraded = "degraded"
+)
+
+// Check is a single named probe.
+type Check struct {
+	Name string
+
+	// Critical checks take the instance out of rotation when they fail.
+	Critical bool
+
+	Timeout time.Duration
+	Run     func(ctx context.Context) error
+}
+
+// Result is the outcome of one check.
+type Result struct {
+	Name      string  `json:"name"`
+	Status    string  `json:"status"`
+	Critical  bool    `json:"critical"`
+	LatencyMS float64 `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// Report is the outcome of running every registered check.
+type Report struct {
+	Status    string   `json:"status"`
+	Checks    []Result `json:"checks"`
+	Timestamp int64    `json:"timestamp"`
+}
+
+// Healthy reports whether every critical check passed.
+func (r Report) Healthy() bool {
+	return r.Status != StatusDown
+}
+
+// Registry holds the checks to run.
+type Registry struct {
+	mu     sync.RWMutex
+	checks []Check
+}
+
+func NewRegistry() *Registry {
+	return &Registry{}
+}
+
+// Register adds a check. Checks are reported in registration order.
+func (r *Registry) Register(c Check) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.checks = append(r.checks, c)
+}
+
+// Run executes every check concurrently.
+func (r *Registry) Run(ctx context.Context) Report {
+	r.mu.RLock()
+	checks := append([]Check(nil), r.checks...)
+	r.mu.RUnlock()
+
+	results := make([]Result, len(checks))
+	var wg sync.WaitGroup
+	for i, c := range checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = run(ctx, c)
+		}()
+	}
+	wg.Wait()
+
+	report := Report{Status: StatusUp, Checks: results, Timestamp: time.Now().Unix()}
+	for _, res := range results {
+		if res.Status == StatusUp {
+			continue
+		}
+		if res.Critical {
+			report.Status = StatusDown
+		} else if report.Status == StatusUp {
+			report.Status = StatusDegraded
+		}
+	}
+	return report
+}
+
+func run(ctx context.Context, c Check) Result {
+	timeout := c.Timeout
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := c.Run(ctx)
+	res := Result{
+		Name:      c.Name,
+		Status:    StatusUp,
+		Critical:  c.Critical,
+		LatencyMS: float64(time.Since(start).Microseconds()) / 1000,
+	}
+	if err != nil {
+		res.Status = StatusDown
+		res.Error = err.Error()
+	}
+	return res
+}
@@ -0,0 +1,84 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func check(name string, critical bool, err error) Check {
+	return Check{Name: name, Critical: critical, Run: func(context.Context) error { return err }}
+}
+
+func TestRegistry_Status(t *testing.T) {
+	cases := []struct {
+		name   string
+		checks []Check
+		want   string
+	}{
+		{"no checks", nil, StatusUp},
+		{"all up", []Check{check("db", true, nil), check("pool", false, nil)}, StatusUp},
+		{"non-critical down", []Check{check("db", true, nil), check("pool", false, errors.New("busy"))}, StatusDegraded},
+		{"critical down", []Check{check("db", true, errors.New("refused")), check("pool", false, errors.New("busy"))}, StatusDown},
+	}
+
+	for _, tc := range cases {
+		r := NewRegistry()
+		for _, c := range tc.checks {
+			r.Register(c)
+		}
+
+		report := r.Run(context.Background())
+		if report.Status != tc.want {
+			t.Errorf("%s: expected %s, got %s", tc.name, tc.want, report.Status)
+		}
+		if len(report.Checks) != len(tc.checks) {
+			t.Errorf("%s: expected %d results, got %d", tc.name, len(tc.checks), len(report.Checks))
+		}
+	}
+}
+
+func TestRegistry_Timeout(t *testing.T) {
+	r := NewRegistry()
+	r.Register(Check{
+		Name:     "hangs",
+		Critical: true,
+		Timeout:  20 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	start := time.Now()
+	report := r.Run(context.Background())
+	if time.Since(start) > time.Second {
+		t.Error("Check timeout was not enforced")
+	}
+	if report.Status != StatusDown || report.Checks[0].Error == "" {
+		t.Errorf("Timed out critical check should be down with an error, got %+v", report)
+	}
+}
+
+func TestHeartbeat(t *testing.T) {
+	var hb Heartbeat
+	c := hb.Check("worker", 50*time.Millisecond)
+
+	if c.Run(context.Background()) == nil {
+		t.Error("Worker that never ran should fail")
+	}
+
+	hb.Beat()
+	if err := c.Run(context.Background()); err != nil {
+		t.Errorf("Fresh heartbeat should pass, got %v", err)
+	}
+
+	time.Sleep(80 * time.Millisecond)
+	if c.Run(context.Background()) == nil {
+		t.Error("Stale heartbeat should fail")
+	}
+
+	var nilHeartbeat *Heartbeat
+	nilHeartbeat.Beat() // must not panic
+}
@@ -0,0 +1,149 @@
+// Package logging configures the process-wide slog logger.
+//
+// Every line is JSON. Lines logged with a request's context carry that
+// request's ID, its trace ID when traced and, once known, the current
+// user's ID and role. Email addresses are masked wherever they appear in a
+// string value, so handlers can log them as ordinary attributes without
+// leaking PII.
+package logging
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"regexp"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ParseLevel accepts debug, info, warn or error, case-insensitively.
+func ParseLevel(s string) (slog.Level, error) {
+	var l slog.Level
+	if err := l.UnmarshalText([]byte(s)); err != nil {
+		return 0, fmt.Errorf("unknown log level %q", s)
+	}
+	return l, nil
+}
+
+// New returns a JSON logger writing to w at the given level.
+func New(w io.Writer, level slog.Level) *slog.Logger {
+	json := slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       level,
+		ReplaceAttr: redactAttr,
+	})
+	return slog.New(&handler{next: json})
+}
+
+// handler adds the request attributes and trace ID found in the context to
+// each record.
+type handler struct {
+	next slog.Handler
+}
+
+func (h *handler) Enabled(ctx context.Context, level slog.Level) bool {
+	return h.next.Enabled(ctx, level)
+}
+
+func (h *handler) Handle(ctx context.Context, r slog.Record) error {
+	if info := fromContext(ctx); info != nil {
+		r.AddAttrs(info.attrs()...)
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		r.AddAttrs(slog.String("trace_id", sc.TraceID().String()))
+	}
+	return h.next.Handle(ctx, r)
+}
+
+func (h *handler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	return &handler{next: h.next.WithAttrs(attrs)}
+}
+
+func (h *handler) WithGroup(name string) slog.Handler {
+	return &handler{next: h.next.WithGroup(name)}
+}
+
+// requestInfo is shared by everything handling one request. The user is
+// filled in by middleware that runs after the request ID is assigned, so
+// it is guarded rather than copied into a new context.
+type requestInfo struct {
+	id string
+
+	mu     sync.RWMutex
+	userID string
+	role   string
+}
+
+func (i *requestInfo) attrs() []slog.Attr {
+	attrs := []slog.Attr{slog.String("request_id", i.id)}
+
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+	if i.userID != "" {
+		attrs = append(attrs, slog.String("user_id", i.userID), slog.String("role", i.role))
+	}
+	return attrs
+}
+
+type contextKey struct{}
+
+func fromContext(ctx context.Context) *requestInfo {
+	info, _ := ctx.Value(contextKey{}).(*requestInfo)
+	return info
+}
+
+// WithRequestID returns a context whose log lines carry id.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, &requestInfo{id: id})
+}
+
+// RequestID returns the request ID stored by WithRequestID, if any.
+func RequestID(ctx context.Context) string {
+	if info := fromContext(ctx); info != nil {
+		return info.id
+	}
+	return ""
+}
+
+// SetUser attaches the current user to every later log line for the
+// request in ctx. It does nothing if ctx has no request ID.
+func SetUser(ctx context.Context, userID, role string) {
+	info := fromContext(ctx)
+	if info == nil {
+		return
+	}
+	info.mu.Lock()
+	info.userID, info.role = userID, role
+	info.mu.Unlock()
+}
+
+var emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+\-]+)@([A-Za-z0-9.\-]+\.[A-Za-z]{2,})`)
+
+// RedactEmail masks the local part of every email address in s, keeping
+// its first character and the domain: jane.doe@school.edu becomes
+// j***@school.edu.
+func RedactEmail(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllStringFunc(s, func(m string) string {
+		at := strings.LastIndexByte(m, '@')
+		return m[:1] + "***" + m[at:]
+	})
+}
+
+// redactAttr masks emails in string values and in errors, whose messages
+// often quote the input that caused them.
+func redactAttr(_ []string, a slog.Attr) slog.Attr {
+	switch a.Value.Kind() {
+	case slog.KindString:
+		a.Value = slog.StringValue(RedactEmail(a.Value.String()))
+	case slog.KindAny:
+		if err, ok := a.Value.Any().(error); ok {
+			a.Value = slog.StringValue(RedactEmail(err.Error()))
+		}
+	}
+	return a
+}
@@ -0,0 +1,93 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func decode(t *testing.T, buf *bytes.Buffer) map[string]any {
+	t.Helper()
+
+	var line map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &line); err != nil {
+		t.Fatalf("Expected one JSON line, got %q: %v", buf.String(), err)
+	}
+	return line
+}
+
+func TestRedactEmail(t *testing.T) {
+	cases := map[string]string{
+		"jane.doe@school.edu":                 "j***@school.edu",
+		"from a@b.co to bob+x@mail.school.ac": "from a***@b.co to b***@mail.school.ac",
+		"no address here":                     "no address here",
+		"@handle":                             "@handle",
+	}
+	for in, want := range cases {
+		if got := RedactEmail(in); got != want {
+			t.Errorf("RedactEmail(%q): expected %q, got %q", in, want, got)
+		}
+	}
+}
+
+func TestLogger_RedactsEmails(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.Info("login for amani@school.edu",
+		"email", "amani@school.edu",
+		"error", errors.New(`staff "amani@school.edu" not found`),
+	)
+
+	if strings.Contains(buf.String(), "amani@") {
+		t.Errorf("Expected every email to be masked, got %s", buf.String())
+	}
+	if line := decode(t, &buf); line["email"] != "a***@school.edu" {
+		t.Errorf("Expected a***@school.edu, got %v", line["email"])
+	}
+}
+
+func TestLogger_AddsRequestAttributes(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	ctx := WithRequestID(context.Background(), "req-1")
+	SetUser(ctx, "42", "admin")
+	logger.InfoContext(ctx, "hello")
+
+	line := decode(t, &buf)
+	if line["request_id"] != "req-1" {
+		t.Errorf("Expected request_id req-1, got %v", line["request_id"])
+	}
+	if line["user_id"] != "42" || line["role"] != "admin" {
+		t.Errorf("Expected user 42 with role admin, got %v/%v", line["user_id"], line["role"])
+	}
+}
+
+func TestLogger_AnonymousRequest(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, slog.LevelInfo)
+
+	logger.InfoContext(WithRequestID(context.Background(), "req-2"), "hello")
+
+	line := decode(t, &buf)
+	if _, ok := line["user_id"]; ok {
+		t.Errorf("Expected no user_id before login, got %v", line["user_id"])
+	}
+	if RequestID(context.Background()) != "" {
+		t.Error("Expected no request ID on a bare context")
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	if l, err := ParseLevel("WARN"); err != nil || l != slog.LevelWarn {
+		t.Errorf("Expected warn, got %v (%v)", l, err)
+	}
+	if _, err := ParseLevel("loud"); err == nil {
+		t.Error("Expected an error for an unknown level")
+	}
+}
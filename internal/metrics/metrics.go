@@ -0,0 +1,134 @@
+// Package metrics exposes Prometheus metrics for the API.
+//
+// Each Metrics has its own registry, so routers built in tests never clash
+// over duplicate registrations.
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "elimu"
+
+// unmatchedRoute labels requests that hit no route.
+const unmatchedRoute = "unmatched"
+
+// Login failure reasons, used as the reason label of the logins counter.
+const (
+	LoginStateMismatch = "state_mismatch"
+	LoginMissingCode   = "missing_code"
+	LoginTokenExchange = "token_exchange_failed"
+	LoginUserInfo      = "userinfo_failed"
+	LoginGoogleTimeout = "google_timeout"
+	LoginNotRegistered = "not_registered"
+	LoginDatabaseError = "database_error"
+)
+
+// Metrics holds the API's collectors. A nil *Metrics records nothing, so
+// handlers built without metrics still work.
+type Metrics struct {
+	registry *prometheus.Registry
+
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	logins   *prometheus.CounterVec
+}
+
+// New creates the collectors along with the Go runtime and process
+// collectors.
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "requests_total",
+			Help:      "HTTP requests by method, route template and status.",
+		}, []string{"method", "route", "status"}),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "http",
+			Name:      "request_duration_seconds",
+			Help:      "HTTP request latency by method, route template and status.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"method", "route", "status"}),
+		logins: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "auth",
+			Name:      "logins_total",
+			Help:      "Google login attempts by result and failure reason.",
+		}, []string{"result", "reason"}),
+	}
+
+	m.registry.MustRegister(
+		m.requests,
+		m.duration,
+		m.logins,
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+// Handler serves the registry in the Prometheus exposition format.
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// Middleware records every request under its route template rather than
+// its path, so IDs in URLs don't explode the label set.
+func (m *Metrics) Middleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		route := c.FullPath()
+		if route == "" {
+			route = unmatchedRoute
+		}
+		status := strconv.Itoa(c.Writer.Status())
+
+		m.requests.WithLabelValues(c.Request.Method, route, status).Inc()
+		m.duration.WithLabelValues(c.Request.Method, route, status).Observe(time.Since(start).Seconds())
+	}
+}
+
+// LoginSucceeded counts a completed login.
+func (m *Metrics) LoginSucceeded() {
+	if m == nil {
+		return
+	}
+	m.logins.WithLabelValues("success", "").Inc()
+}
+
+// LoginFailed counts a failed login; reason is one of the Login constants.
+func (m *Metrics) LoginFailed(reason string) {
+	if m == nil {
+		return
+	}
+	m.logins.WithLabelValues("failure", reason).Inc()
+}
+
+// SessionCount reports the number of active sessions whenever metrics are
+// scraped.
+func (m *Metrics) SessionCount(count func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Subsystem: "auth",
+		Name:      "active_sessions",
+		Help:      "Sessions currently stored.",
+	}, func() float64 { return float64(count()) }))
+}
+
+// Pool exports pgxpool statistics.
+func (m *Metrics) Pool(pool *pgxpool.Pool) {
+	m.registry.MustRegister(newPoolCollector(pool.Stat))
+}
@@ -0,0 +1,64 @@
+package metrics
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+func TestMiddleware_LabelsByRouteTemplate(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	m := New()
+	r := gin.New()
+	r.Use(m.Middleware())
+	r.GET("/grants/:id", func(c *gin.Context) { c.Status(http.StatusNoContent) })
+
+	for _, path := range []string{"/grants/1", "/grants/2", "/nowhere"} {
+		r.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", path, nil))
+	}
+
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", "/grants/:id", "204")); got != 2 {
+		t.Errorf("Expected 2 requests for /grants/:id, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.requests.WithLabelValues("GET", unmatchedRoute, "404")); got != 1 {
+		t.Errorf("Expected 1 unmatched request, got %v", got)
+	}
+}
+
+func TestLogins(t *testing.T) {
+	m := New()
+	m.LoginSucceeded()
+	m.LoginFailed(LoginNotRegistered)
+	m.LoginFailed(LoginNotRegistered)
+
+	if got := testutil.ToFloat64(m.logins.WithLabelValues("success", "")); got != 1 {
+		t.Errorf("Expected 1 successful login, got %v", got)
+	}
+	if got := testutil.ToFloat64(m.logins.WithLabelValues("failure", LoginNotRegistered)); got != 2 {
+		t.Errorf("Expected 2 not_registered failures, got %v", got)
+	}
+
+	var none *Metrics
+	none.LoginSucceeded()
+	none.LoginFailed(LoginStateMismatch)
+}
+
+func TestHandler_ExposesSessionGauge(t *testing.T) {
+	m := New()
+	m.SessionCount(func() int { return 3 })
+
+	w := httptest.NewRecorder()
+	m.Handler().ServeHTTP(w, httptest.NewRequest("GET", "/metrics", nil))
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", w.Code)
+	}
+	if !strings.Contains(w.Body.String(), "elimu_auth_active_sessions 3") {
+		t.Errorf("Expected the session gauge in:\n%s", w.Body.String())
+	}
+}
@@ -1,10 +1,10 @@
 package middleware
 
 import (
-	"net/http"
-	"sync"
-
+	"elimu-go/internal/logging"
 	"elimu-go/internal/models"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
 
 	"github.com/gin-gonic/gin"
 )
@@ -13,35 +13,44 @@ type contextKey string
 
 const CurrentUserKey contextKey = "current_user"
 
-func RequireLogin(sessions *sync.Map) gin.HandlerFunc {
+func RequireLogin(sessions repository.SessionRepository) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		sessionID, err := c.Cookie("session_id")
 		if err != nil {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "not logged in"})
-			c.Abort()
+			problem.Abort(c, problem.NotLoggedIn, "")
 			return
 		}
 
-		user, ok := sessions.Load(sessionID)
+		u, ok := sessions.Get(sessionID)
 		if !ok {
-			c.JSON(http.StatusUnauthorized, gin.H{"error": "session expired"})
-			c.Abort()
+			problem.Abort(c, problem.SessionExpired, "")
 			return
 		}
 
-		u, ok := user.(*models.User)
-		println("User", u.ID, u.Role, u.Email, "Wha", ok)
-		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid session user"})
-			c.Abort()
-			return
-		}
+		setUser(c, u)
+		c.Next()
+	}
+}
 
-		c.Set(string(CurrentUserKey), u)
+// IdentifyUser tags the request's log lines with the logged-in user, if
+// any. Unlike RequireLogin it never rejects a request.
+func IdentifyUser(sessions repository.SessionRepository) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if sessionID, err := c.Cookie("session_id"); err == nil {
+			if u, ok := sessions.Get(sessionID); ok {
+				logging.SetUser(c.Request.Context(), u.ID, u.Role)
+			}
+		}
 		c.Next()
 	}
 }
 
+// setUser stores u for later handlers and tags the request's log lines.
+func setUser(c *gin.Context, u *models.User) {
+	c.Set(string(CurrentUserKey), u)
+	logging.SetUser(c.Request.Context(), u.ID, u.Role)
+}
+
 func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 	roleSet := make(map[string]struct{}, len(allowedRoles))
 	for _, r := range allowedRoles {
@@ -51,21 +60,18 @@ func RequireRole(allowedRoles ...string) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		u, exists := c.Get(string(CurrentUserKey))
 		if !exists {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "user missing from context"})
-			c.Abort()
+			problem.Abort(c, problem.Internal, "user missing from context")
 			return
 		}
 
 		user, ok := u.(*models.User)
 		if !ok {
-			c.JSON(http.StatusInternalServerError, gin.H{"error": "invalid user in context"})
-			c.Abort()
+			problem.Abort(c, problem.Internal, "invalid user in context")
 			return
 		}
 
 		if _, allowed := roleSet[user.Role]; !allowed {
-			c.JSON(http.StatusForbidden, gin.H{"error": "insufficient permissions"})
-			c.Abort()
+			problem.Abort(c, problem.Forbidden, "")
 			return
 		}
 
@@ -0,0 +1,113 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/logging"
+	"elimu-go/internal/models"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+)
+
+// captureLogs routes the default logger into a buffer for one test.
+func captureLogs(t *testing.T) *bytes.Buffer {
+	t.Helper()
+
+	var buf bytes.Buffer
+	prev := slog.Default()
+	slog.SetDefault(logging.New(&buf, slog.LevelDebug))
+	t.Cleanup(func() { slog.SetDefault(prev) })
+	return &buf
+}
+
+func newLoggedRouter(sessions repository.SessionRepository) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(RequestID(), AccessLog(), Recovery(), IdentifyUser(sessions))
+	r.GET("/ok", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/panic", func(c *gin.Context) { panic("boom") })
+	return r
+}
+
+func TestRequestID_PropagatesValidHeader(t *testing.T) {
+	captureLogs(t)
+	router := newLoggedRouter(repository.NewMemorySessionRepository())
+
+	req := httptest.NewRequest("GET", "/ok", nil)
+	req.Header.Set(RequestIDHeader, "abc-123")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	if got := w.Header().Get(RequestIDHeader); got != "abc-123" {
+		t.Errorf("Expected abc-123 to be echoed, got %q", got)
+	}
+}
+
+func TestRequestID_ReplacesInvalidHeader(t *testing.T) {
+	captureLogs(t)
+	router := newLoggedRouter(repository.NewMemorySessionRepository())
+
+	for _, id := range []string{"", "has space", strings.Repeat("x", maxRequestIDLength+1)} {
+		req := httptest.NewRequest("GET", "/ok", nil)
+		req.Header.Set(RequestIDHeader, id)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		got := w.Header().Get(RequestIDHeader)
+		if got == "" || got == id {
+			t.Errorf("Expected %q to be replaced, got %q", id, got)
+		}
+	}
+}
+
+func TestAccessLog_TagsUser(t *testing.T) {
+	logs := captureLogs(t)
+	sessions := repository.NewMemorySessionRepository()
+	sessions.Save("s1", &models.User{ID: "7", Email: "amani@school.edu", Role: "staff"})
+	router := newLoggedRouter(sessions)
+
+	req := httptest.NewRequest("GET", "/ok", nil)
+	req.Header.Set(RequestIDHeader, "req-7")
+	req.AddCookie(&http.Cookie{Name: "session_id", Value: "s1"})
+	router.ServeHTTP(httptest.NewRecorder(), req)
+
+	var line map[string]any
+	if err := json.Unmarshal(logs.Bytes(), &line); err != nil {
+		t.Fatalf("Expected one JSON access log line, got %q", logs.String())
+	}
+	want := map[string]any{
+		"msg": "request", "route": "/ok", "request_id": "req-7",
+		"user_id": "7", "role": "staff", "status": float64(200),
+	}
+	for k, v := range want {
+		if line[k] != v {
+			t.Errorf("Expected %s=%v, got %v", k, v, line[k])
+		}
+	}
+}
+
+func TestRecovery_LogsPanic(t *testing.T) {
+	logs := captureLogs(t)
+	router := newLoggedRouter(repository.NewMemorySessionRepository())
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/panic", nil))
+
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("Expected 500, got %d", w.Code)
+	}
+	if !strings.Contains(logs.String(), `"panic":"boom"`) {
+		t.Errorf("Expected the panic to be logged, got %s", logs.String())
+	}
+	if !strings.Contains(logs.String(), `"status":500`) {
+		t.Errorf("Expected the access log to record 500, got %s", logs.String())
+	}
+}
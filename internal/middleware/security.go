@@ -0,0 +1,115 @@
+package middleware
+
+import (
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"elimu-go/internal/config"
+	"elimu-go/internal/problem"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Content security policies. The API only serves JSON, so its policy
+// forbids everything; the swagger UI needs its own scripts, styles and
+// inline bootstrap code.
+const (
+	APIContentSecurityPolicy     = "default-src 'none'; frame-ancestors 'none'; base-uri 'none'; form-action 'none'"
+	SwaggerContentSecurityPolicy = "default-src 'self'; script-src 'self' 'unsafe-inline'; style-src 'self' 'unsafe-inline'; " +
+		"img-src 'self' data:; frame-ancestors 'none'; base-uri 'self'; form-action 'self'"
+)
+
+// hstsMaxAge is how long browsers remember to use HTTPS only.
+const hstsMaxAge = 2 * 365 * 24 * time.Hour
+
+// preflightMaxAge is how long browsers may cache a preflight response.
+const preflightMaxAge = 10 * time.Minute
+
+// allowedRequestHeaders are the headers cross-origin requests may send.
+var allowedRequestHeaders = []string{"Content-Type", RequestIDHeader}
+
+// SecurityHeaders sets the headers every response carries. HSTS is only
+// sent when hsts is set, since it pins browsers to HTTPS for two years and
+// would break plain-HTTP development setups.
+func SecurityHeaders(hsts bool) gin.HandlerFunc {
+	hstsValue := "max-age=" + strconv.Itoa(int(hstsMaxAge.Seconds())) + "; includeSubDomains"
+
+	return func(c *gin.Context) {
+		h := c.Writer.Header()
+		h.Set("X-Content-Type-Options", "nosniff")
+		h.Set("X-Frame-Options", "DENY")
+		h.Set("Referrer-Policy", "strict-origin-when-cross-origin")
+		h.Set("Content-Security-Policy", APIContentSecurityPolicy)
+		if hsts {
+			h.Set("Strict-Transport-Security", hstsValue)
+		}
+		c.Next()
+	}
+}
+
+// ContentSecurityPolicy replaces the policy set by SecurityHeaders for the
+// routes it is applied to.
+func ContentSecurityPolicy(policy string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Header("Content-Security-Policy", policy)
+		c.Next()
+	}
+}
+
+// CORS lets the configured origins call the API from a browser. Requests
+// from other origins are served without CORS headers, so the browser
+// withholds the response; their preflights are refused outright.
+func CORS(cfg config.CORS) gin.HandlerFunc {
+	anyOrigin := slices.Contains(cfg.AllowedOrigins, "*")
+	methods := strings.Join(cfg.AllowedMethods, ", ")
+	headers := strings.Join(allowedRequestHeaders, ", ")
+	maxAge := strconv.Itoa(int(preflightMaxAge.Seconds()))
+
+	return func(c *gin.Context) {
+		origin := c.GetHeader("Origin")
+		if origin == "" {
+			c.Next()
+			return
+		}
+
+		h := c.Writer.Header()
+		h.Add("Vary", "Origin")
+
+		preflight := c.Request.Method == http.MethodOptions &&
+			c.GetHeader("Access-Control-Request-Method") != ""
+
+		if !anyOrigin && !slices.Contains(cfg.AllowedOrigins, origin) {
+			if preflight {
+				problem.Abort(c, problem.OriginNotAllowed, origin+" may not call this API")
+				return
+			}
+			c.Next()
+			return
+		}
+
+		if anyOrigin && !cfg.AllowCredentials {
+			h.Set("Access-Control-Allow-Origin", "*")
+		} else {
+			h.Set("Access-Control-Allow-Origin", origin)
+		}
+		if cfg.AllowCredentials {
+			h.Set("Access-Control-Allow-Credentials", "true")
+		}
+		h.Set("Access-Control-Expose-Headers", RequestIDHeader)
+
+		if !preflight {
+			c.Next()
+			return
+		}
+
+		h.Add("Vary", "Access-Control-Request-Method")
+		h.Add("Vary", "Access-Control-Request-Headers")
+		h.Set("Access-Control-Allow-Methods", methods)
+		h.Set("Access-Control-Allow-Headers", headers)
+		h.Set("Access-Control-Max-Age", maxAge)
+		c.AbortWithStatus(http.StatusNoContent)
+	}
+}
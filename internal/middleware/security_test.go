@@ -0,0 +1,114 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"elimu-go/internal/config"
+
+	"github.com/gin-gonic/gin"
+)
+
+func newCORSRouter(cfg config.CORS) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.Use(SecurityHeaders(true), CORS(cfg))
+	r.GET("/me", func(c *gin.Context) { c.Status(http.StatusOK) })
+	r.GET("/swagger", ContentSecurityPolicy(SwaggerContentSecurityPolicy), func(c *gin.Context) { c.Status(http.StatusOK) })
+	return r
+}
+
+func preflight(router *gin.Engine, origin string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodOptions, "/me", nil)
+	req.Header.Set("Origin", origin)
+	req.Header.Set("Access-Control-Request-Method", "GET")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	return w
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	router := newCORSRouter(config.CORS{})
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/me", nil))
+
+	for header, want := range map[string]string{
+		"X-Content-Type-Options":  "nosniff",
+		"Referrer-Policy":         "strict-origin-when-cross-origin",
+		"Content-Security-Policy": APIContentSecurityPolicy,
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s: expected %q, got %q", header, want, got)
+		}
+	}
+	if w.Header().Get("Strict-Transport-Security") == "" {
+		t.Error("Expected HSTS when enabled")
+	}
+
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest("GET", "/swagger", nil))
+	if got := w.Header().Get("Content-Security-Policy"); got != SwaggerContentSecurityPolicy {
+		t.Errorf("Expected the swagger policy, got %q", got)
+	}
+}
+
+func TestCORS_AllowedOrigin(t *testing.T) {
+	router := newCORSRouter(config.CORS{
+		AllowedOrigins:   []string{"https://app.elimu.test"},
+		AllowCredentials: true,
+		AllowedMethods:   []string{"GET", "POST"},
+	})
+
+	w := preflight(router, "https://app.elimu.test")
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("Expected 204 for an allowed preflight, got %d", w.Code)
+	}
+	for header, want := range map[string]string{
+		"Access-Control-Allow-Origin":      "https://app.elimu.test",
+		"Access-Control-Allow-Credentials": "true",
+		"Access-Control-Allow-Methods":     "GET, POST",
+	} {
+		if got := w.Header().Get(header); got != want {
+			t.Errorf("%s: expected %q, got %q", header, want, got)
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Origin", "https://app.elimu.test")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if w.Code != http.StatusOK || w.Header().Get("Access-Control-Allow-Origin") != "https://app.elimu.test" {
+		t.Errorf("Expected the actual request to be allowed, got %d %v", w.Code, w.Header())
+	}
+}
+
+func TestCORS_DisallowedOrigin(t *testing.T) {
+	router := newCORSRouter(config.CORS{AllowedOrigins: []string{"https://app.elimu.test"}})
+
+	if w := preflight(router, "https://evil.test"); w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403 for a foreign preflight, got %d", w.Code)
+	}
+
+	req := httptest.NewRequest("GET", "/me", nil)
+	req.Header.Set("Origin", "https://evil.test")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "" {
+		t.Errorf("Expected no CORS headers for a foreign origin, got %q", got)
+	}
+}
+
+func TestCORS_AnyOrigin(t *testing.T) {
+	router := newCORSRouter(config.CORS{AllowedOrigins: []string{"*"}})
+
+	w := preflight(router, "https://anywhere.test")
+	if got := w.Header().Get("Access-Control-Allow-Origin"); got != "*" {
+		t.Errorf("Expected *, got %q", got)
+	}
+	if got := w.Header().Get("Access-Control-Allow-Credentials"); got != "" {
+		t.Errorf("Expected no credentials for any origin, got %q", got)
+	}
+}
@@ -0,0 +1,69 @@
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"testing"
+)
+
+func TestDocument_WriteTo(t *testing.T) {
+	d := New("Sign-in (CS101)")
+	d.Text(40, 60, 14, true, "CS101 (A) \\ Intro")
+	d.Line(40, 70, 555, 70)
+	d.AddPage()
+	d.Text(40, 60, 10, false, "Wanjirũ Müller")
+
+	var buf bytes.Buffer
+	if _, err := d.WriteTo(&buf); err != nil {
+		t.Fatalf("WriteTo: %v", err)
+	}
+	out := buf.Bytes()
+
+	if !bytes.HasPrefix(out, []byte("%PDF-1.4\n")) || !bytes.HasSuffix(out, []byte("%%EOF\n")) {
+		t.Fatalf("Expected a PDF header and trailer, got %q", out)
+	}
+	if !bytes.Contains(out, []byte("/Count 2")) {
+		t.Error("Expected two pages")
+	}
+	if !bytes.Contains(out, []byte(`(CS101 \(A\) \\ Intro) Tj`)) {
+		t.Error("Expected parentheses and backslashes to be escaped")
+	}
+	if !bytes.Contains(out, []byte(`(Wanjir? M\374ller) Tj`)) {
+		t.Error("Expected Latin-1 text encoded and other characters replaced")
+	}
+
+	// Every xref entry must point at the start of its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	if m == nil {
+		t.Fatal("Expected startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	if !bytes.HasPrefix(out[xref:], []byte("xref\n")) {
+		t.Fatalf("startxref %d does not point at the xref table", xref)
+	}
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	if len(entries) != 9 {
+		t.Fatalf("Expected 9 objects, got %d", len(entries))
+	}
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		if want := fmt.Sprintf("%d 0 obj\n", i+1); !bytes.HasPrefix(out[off:], []byte(want)) {
+			t.Errorf("xref entry %d points at %q", i+1, out[off:off+10])
+		}
+	}
+}
+
+func TestFit(t *testing.T) {
+	cases := map[string]string{
+		"short":                 "short",
+		"exactly ten":           "exactly...",
+		"Wanjirũ Müller-Otieno": "Wanjirũ...",
+	}
+	for in, want := range cases {
+		if got := Fit(in, 10); got != want {
+			t.Errorf("Fit(%q, 10) = %q, want %q", in, got, want)
+		}
+	}
+}
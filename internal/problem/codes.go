@@ -0,0 +1,80 @@
+package problem
+
+import "net/http"
+
+// Code is a stable error code with the status and title it is reported
+// with. IDs are part of the API: clients switch on them, so never rename
+// one.
+type Code struct {
+	ID     string
+	Status int
+	Title  string
+}
+
+// Authentication and authorization.
+var (
+	NotLoggedIn    = Code{"auth.not_logged_in", http.StatusUnauthorized, "Not logged in"}
+	SessionExpired = Code{"auth.session_expired", http.StatusUnauthorized, "Session expired"}
+	Forbidden      = Code{"auth.forbidden", http.StatusForbidden, "Insufficient permissions"}
+	InvalidState   = Code{"auth.invalid_state", http.StatusBadRequest, "Invalid state parameter"}
+	MissingCode    = Code{"auth.missing_code", http.StatusBadRequest, "No code provided"}
+	NotRegistered  = Code{"auth.not_registered", http.StatusForbidden, "User not registered in Elimu"}
+	TokenExchange  = Code{"auth.token_exchange_failed", http.StatusInternalServerError, "Token exchange failed"}
+	UserInfo       = Code{"auth.userinfo_failed", http.StatusInternalServerError, "Failed to get user info"}
+	GoogleTimeout  = Code{"auth.google_timeout", http.StatusServiceUnavailable, "Google did not respond in time"}
+	SelfApproval   = Code{"auth.self_approval", http.StatusForbidden, "Cannot decide a request you made or that elevates you"}
+)
+
+// Roles.
+var (
+	StaffNotFound         = Code{"staff.not_found", http.StatusNotFound, "Staff member not found"}
+	RoleGrantNotFound     = Code{"role_grant.not_found", http.StatusNotFound, "Role grant not found"}
+	RoleRequestNotFound   = Code{"role_request.not_found", http.StatusNotFound, "Role request not found"}
+	RoleRequestNotPending = Code{"role_request.not_pending", http.StatusConflict, "Role request already decided"}
+	RoleRequestExpired    = Code{"role_request.expired", http.StatusConflict, "Role request expired"}
+)
+
+// Courses.
+var (
+	CourseNotFound  = Code{"course.not_found", http.StatusNotFound, "Course not found"}
+	CourseCodeTaken = Code{"course.code_taken", http.StatusConflict, "Course code already in use"}
+	CourseInUse     = Code{"course.in_use", http.StatusConflict, "Course is still referenced"}
+	CourseNotOwner  = Code{"course.not_owner", http.StatusForbidden, "Not an owner of this course"}
+	RequisiteCycle  = Code{"course.requisite_cycle", http.StatusConflict, "Requisites would form a cycle"}
+	StudentNotFound = Code{"student.not_found", http.StatusNotFound, "Student not found"}
+)
+
+// Terms and offerings.
+var (
+	TermNotFound     = Code{"term.not_found", http.StatusNotFound, "Term not found"}
+	TermCodeTaken    = Code{"term.code_taken", http.StatusConflict, "Term code already in use"}
+	OfferingNotFound = Code{"offering.not_found", http.StatusNotFound, "Course offering not found"}
+	SectionTaken     = Code{"offering.section_taken", http.StatusConflict, "Section already exists in the term"}
+	NotInstructor    = Code{"offering.not_instructor", http.StatusForbidden, "Not an instructor of this offering"}
+	RoomBooked       = Code{"offering.room_booked", http.StatusConflict, "Room is already booked at that time"}
+	OfferingInUse    = Code{"offering.has_enrollments", http.StatusConflict, "Offering still has students"}
+)
+
+// Enrollment.
+var (
+	EnrollmentNotFound   = Code{"enrollment.not_found", http.StatusNotFound, "Enrollment not found"}
+	AlreadyEnrolled      = Code{"enrollment.already_enrolled", http.StatusConflict, "Already enrolled or waitlisted"}
+	OfferingFull         = Code{"enrollment.offering_full", http.StatusConflict, "Offering is full"}
+	EnrollmentNotOpen    = Code{"enrollment.not_open", http.StatusForbidden, "Enrollment has not opened"}
+	EnrollmentClosed     = Code{"enrollment.closed", http.StatusForbidden, "Enrollment has closed"}
+	AddDropClosed        = Code{"enrollment.add_drop_closed", http.StatusForbidden, "The add/drop deadline has passed"}
+	OverrideNotFound     = Code{"enrollment.override_not_found", http.StatusNotFound, "Enrollment override not found"}
+	TimetableClash       = Code{"enrollment.timetable_clash", http.StatusConflict, "Offering clashes with your timetable"}
+	NotificationNotFound = Code{"notification.not_found", http.StatusNotFound, "Notification not found"}
+)
+
+// Generic problems, used when nothing more specific applies.
+var (
+	Validation       = Code{"request.invalid", http.StatusBadRequest, "Invalid request"}
+	RouteNotFound    = Code{"request.no_route", http.StatusNotFound, "Not found"}
+	OriginNotAllowed = Code{"request.origin_not_allowed", http.StatusForbidden, "Origin not allowed"}
+	NotFound         = Code{"resource.not_found", http.StatusNotFound, "Not found"}
+	Conflict         = Code{"resource.conflict", http.StatusConflict, "Conflict"}
+	Unavailable      = Code{"service.unavailable", http.StatusServiceUnavailable, "Service temporarily unavailable"}
+	Internal         = Code{"internal", http.StatusInternalServerError, "Internal server error"}
+)
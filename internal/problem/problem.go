@@ -0,0 +1,191 @@
+// Package problem writes errors as RFC 7807 problem details.
+//
+// Every error response carries a stable Code the frontend can switch on,
+// the request ID to quote in bug reports and, for invalid input, the
+// fields at fault.
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+
+	"elimu-go/internal/logging"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-playground/validator/v10"
+)
+
+// ContentType is the media type of problem responses.
+const ContentType = "application/problem+json"
+
+// typePrefix turns a code into the problem's type URI. The URIs identify
+// problems; they are not meant to be dereferenced.
+const typePrefix = "urn:elimu:problem:"
+
+// Problem is an RFC 7807 problem details object.
+// swagger:model Problem
+type Problem struct {
+	// URI identifying the kind of problem
+	// example: urn:elimu:problem:auth.not_registered
+	Type string `json:"type"`
+
+	// Short summary that does not change between occurrences
+	// example: User not registered in Elimu
+	Title string `json:"title"`
+
+	// HTTP status code
+	// example: 403
+	Status int `json:"status"`
+
+	// Explanation specific to this occurrence
+	Detail string `json:"detail,omitempty"`
+
+	// Path of the request that failed
+	// example: /api/callback
+	Instance string `json:"instance,omitempty"`
+
+	// Stable machine-readable error code
+	// example: auth.not_registered
+	Code string `json:"code"`
+
+	// ID of the request, as sent in X-Request-ID
+	RequestID string `json:"request_id,omitempty"`
+
+	// Fields that failed validation
+	Errors []FieldError `json:"errors,omitempty"`
+
+	// What the request clashed with, such as the meeting already holding
+	// a room
+	Conflict any `json:"conflict,omitempty"`
+}
+
+// FieldError describes one invalid input field. It is also an error, so
+// parsers can return it for the handler to report.
+// swagger:model FieldError
+type FieldError struct {
+	// Field name as the client sent it
+	// example: expires_at
+	Field string `json:"field"`
+
+	// What is wrong with it
+	// example: must be in the future
+	Message string `json:"message"`
+}
+
+func (e FieldError) Error() string {
+	if e.Field == "" {
+		return e.Message
+	}
+	return e.Field + " " + e.Message
+}
+
+// New builds a problem for code. detail may be empty.
+func New(code Code, detail string) *Problem {
+	return &Problem{
+		Type:   typePrefix + code.ID,
+		Title:  code.Title,
+		Status: code.Status,
+		Detail: detail,
+		Code:   code.ID,
+	}
+}
+
+// Write sends p, filling in the request it belongs to, and aborts the
+// handler chain.
+func Write(c *gin.Context, p *Problem) {
+	p.Instance = c.Request.URL.Path
+	p.RequestID = logging.RequestID(c.Request.Context())
+
+	c.Header("Content-Type", ContentType)
+	c.AbortWithStatusJSON(p.Status, p)
+}
+
+// Abort sends the problem for code.
+func Abort(c *gin.Context, code Code, detail string) {
+	Write(c, New(code, detail))
+}
+
+// Invalid sends a validation problem listing the fields err is about. err
+// may be a FieldError, an error from gin's binding, or a join of them.
+func Invalid(c *gin.Context, err error) {
+	p := New(Validation, "One or more fields are invalid")
+	p.Errors = Fields(err)
+	Write(c, p)
+}
+
+// Fields breaks err down into field errors.
+func Fields(err error) []FieldError {
+	var (
+		fe        FieldError
+		verrs     validator.ValidationErrors
+		typeErr   *json.UnmarshalTypeError
+		syntaxErr *json.SyntaxError
+	)
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var fields []FieldError
+		for _, e := range joined.Unwrap() {
+			fields = append(fields, Fields(e)...)
+		}
+		return fields
+	}
+
+	switch {
+	case errors.As(err, &fe):
+		return []FieldError{fe}
+	case errors.As(err, &verrs):
+		fields := make([]FieldError, 0, len(verrs))
+		for _, v := range verrs {
+			fields = append(fields, FieldError{Field: v.Field(), Message: validationMessage(v)})
+		}
+		return fields
+	case errors.As(err, &typeErr):
+		return []FieldError{{Field: typeErr.Field, Message: "must be a " + typeErr.Type.String()}}
+	case errors.As(err, &syntaxErr):
+		return []FieldError{{Message: "body is not valid JSON"}}
+	}
+	return []FieldError{{Message: err.Error()}}
+}
+
+func validationMessage(v validator.FieldError) string {
+	switch v.Tag() {
+	case "required":
+		return "is required"
+	case "email":
+		return "must be an email address"
+	case "max":
+		return "must be at most " + v.Param()
+	case "min":
+		return "must be at least " + v.Param()
+	case "oneof":
+		return "must be one of " + v.Param()
+	}
+	return "failed " + v.Tag() + " validation"
+}
+
+// init makes validation errors name fields by their JSON key, which is
+// what the client sent, rather than the Go field name.
+func init() {
+	v, ok := binding.Validator.Engine().(*validator.Validate)
+	if !ok {
+		return
+	}
+	v.RegisterTagNameFunc(func(f reflect.StructField) string {
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			return ""
+		case "":
+			return f.Name
+		}
+		return name
+	})
+}
+
+// NotFoundHandler answers requests that match no route.
+func NotFoundHandler(c *gin.Context) {
+	Abort(c, RouteNotFound, "No route for "+c.Request.Method+" "+c.Request.URL.Path)
+}
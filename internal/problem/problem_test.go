@@ -0,0 +1,89 @@
+package problem
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/logging"
+
+	"github.com/gin-gonic/gin"
+)
+
+func serve(handler gin.HandlerFunc, body string) (*httptest.ResponseRecorder, Problem) {
+	gin.SetMode(gin.TestMode)
+
+	r := gin.New()
+	r.POST("/things/:id", handler)
+
+	req := httptest.NewRequest("POST", "/things/1", strings.NewReader(body))
+	req = req.WithContext(logging.WithRequestID(req.Context(), "req-9"))
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+
+	var p Problem
+	json.Unmarshal(w.Body.Bytes(), &p)
+	return w, p
+}
+
+func TestAbort(t *testing.T) {
+	w, p := serve(func(c *gin.Context) { Abort(c, NotRegistered, "") }, "")
+
+	if w.Code != http.StatusForbidden {
+		t.Errorf("Expected 403, got %d", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != ContentType {
+		t.Errorf("Expected %s, got %s", ContentType, ct)
+	}
+	if p.Code != "auth.not_registered" || p.Type != "urn:elimu:problem:auth.not_registered" {
+		t.Errorf("Unexpected code/type %q/%q", p.Code, p.Type)
+	}
+	if p.Instance != "/things/1" || p.RequestID != "req-9" {
+		t.Errorf("Expected instance and request ID to be filled in, got %q/%q", p.Instance, p.RequestID)
+	}
+}
+
+func TestInvalid_BindingErrorsUseJSONNames(t *testing.T) {
+	type body struct {
+		Email string `json:"email_address" binding:"required,email"`
+		Role  string `json:"role" binding:"required"`
+	}
+	handler := func(c *gin.Context) {
+		var b body
+		if err := c.ShouldBindJSON(&b); err != nil {
+			Invalid(c, err)
+		}
+	}
+
+	w, p := serve(handler, `{"email_address": "nope"}`)
+
+	if w.Code != http.StatusBadRequest || p.Code != Validation.ID {
+		t.Fatalf("Expected a 400 validation problem, got %d %q", w.Code, p.Code)
+	}
+	want := map[string]string{"email_address": "must be an email address", "role": "is required"}
+	if len(p.Errors) != len(want) {
+		t.Fatalf("Expected %d field errors, got %+v", len(want), p.Errors)
+	}
+	for _, fe := range p.Errors {
+		if want[fe.Field] != fe.Message {
+			t.Errorf("%s: expected %q, got %q", fe.Field, want[fe.Field], fe.Message)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	joined := errors.Join(
+		FieldError{Field: "type", Message: "must be student or staff"},
+		FieldError{Field: "created_after", Message: "must be an RFC3339 timestamp"},
+	)
+	if got := Fields(joined); len(got) != 2 || got[1].Field != "created_after" {
+		t.Errorf("Expected both joined field errors, got %+v", got)
+	}
+
+	if got := Fields(errors.New("boom")); len(got) != 1 || got[0].Message != "boom" {
+		t.Errorf("Expected the error message as a field-less error, got %+v", got)
+	}
+}
@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrCourseNotFound  = fmt.Errorf("course %w", ErrNotFound)
+	ErrCourseCodeTaken = fmt.Errorf("%w: course code is already in use", ErrConflict)
+	ErrCourseInUse     = fmt.Errorf("%w: course is referenced by other records", ErrConflict)
+)
+
+// Course is an entry in the course catalog.
+type Course struct {
+	ID          int           `json:"id"`
+	Code        string        `json:"code"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Credits     int           `json:"credits"`
+	Department  string        `json:"department"`
+	Owners      []CourseOwner `json:"owners"`
+	CreatedAt   time.Time     `json:"created_at"`
+	UpdatedAt   time.Time     `json:"updated_at"`
+}
+
+// CourseOwner is a staff member responsible for a course.
+type CourseOwner struct {
+	StaffID   int    `json:"staff_id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// IsOwner reports whether email belongs to one of the course's owners,
+// ignoring case.
+func (c Course) IsOwner(email string) bool {
+	for _, o := range c.Owners {
+		if strings.EqualFold(o.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// CourseFilter narrows a catalog listing.
+type CourseFilter struct {
+	Department string
+	Page       Page
+}
+
+// CourseRepository stores the course catalog.
+type CourseRepository interface {
+	// CreateCourse stores c owned by the staff with ownerEmails, returning
+	// ErrStaffNotFound if any of them is not staff.
+	CreateCourse(ctx context.Context, c Course, ownerEmails []string) (Course, error)
+	GetCourse(ctx context.Context, id int) (Course, error)
+
+	// ListCourses returns a page of courses ordered by code, along with the
+	// number of courses matching f.
+	ListCourses(ctx context.Context, f CourseFilter) ([]Course, int, error)
+
+	// UpdateCourse replaces the course's details. A nil ownerEmails keeps
+	// the current owners.
+	UpdateCourse(ctx context.Context, c Course, ownerEmails []string) (Course, error)
+	DeleteCourse(ctx context.Context, id int) error
+
+	// SearchCourses returns a page of the courses matching s, best match
+	// first, with facets over every match.
+	SearchCourses(ctx context.Context, s CourseSearch) (CourseSearchResult, error)
+}
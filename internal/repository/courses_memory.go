@@ -0,0 +1,220 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryCourseRepository is an in-memory CourseRepository for tests.
+// Owners are looked up among the staff of the MemoryUserRepository it was
+// created with.
+type MemoryCourseRepository struct {
+	mu      sync.RWMutex
+	users   *MemoryUserRepository
+	courses []Course
+	nextID  int
+
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func NewMemoryCourseRepository(users *MemoryUserRepository) *MemoryCourseRepository {
+	return &MemoryCourseRepository{users: users}
+}
+
+// owners resolves ownerEmails to staff, as setOwners does.
+func (r *MemoryCourseRepository) owners(ownerEmails []string) ([]CourseOwner, error) {
+	owners := []CourseOwner{}
+	for _, email := range ownerEmails {
+		s, id, ok := r.users.staffMember(email)
+		if !ok {
+			return nil, ErrStaffNotFound
+		}
+		owners = append(owners, CourseOwner{StaffID: id, Email: s.Email, FirstName: s.FirstName, LastName: s.LastName})
+	}
+	return owners, nil
+}
+
+// find returns the index of course id, or -1.
+func (r *MemoryCourseRepository) find(id int) int {
+	return slices.IndexFunc(r.courses, func(c Course) bool { return c.ID == id })
+}
+
+// codeTaken reports whether a course other than id already uses code.
+func (r *MemoryCourseRepository) codeTaken(code string, id int) bool {
+	return slices.ContainsFunc(r.courses, func(c Course) bool { return c.Code == code && c.ID != id })
+}
+
+func (r *MemoryCourseRepository) CreateCourse(_ context.Context, c Course, ownerEmails []string) (Course, error) {
+	if r.Err != nil {
+		return c, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codeTaken(c.Code, 0) {
+		return c, ErrCourseCodeTaken
+	}
+	owners, err := r.owners(ownerEmails)
+	if err != nil {
+		return c, err
+	}
+	r.nextID++
+	c.ID = r.nextID
+	c.Owners = owners
+	c.CreatedAt = time.Now()
+	c.UpdatedAt = c.CreatedAt
+	r.courses = append(r.courses, c)
+	return c, nil
+}
+
+func (r *MemoryCourseRepository) GetCourse(_ context.Context, id int) (Course, error) {
+	if r.Err != nil {
+		return Course{}, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.find(id)
+	if i < 0 {
+		return Course{}, ErrCourseNotFound
+	}
+	return r.courses[i], nil
+}
+
+// course returns course id without checking Err, for the term repository.
+func (r *MemoryCourseRepository) course(id int) (Course, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.find(id)
+	if i < 0 {
+		return Course{}, false
+	}
+	return r.courses[i], true
+}
+
+// courseByCode returns the course with code without checking Err.
+func (r *MemoryCourseRepository) courseByCode(code string) (Course, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := slices.IndexFunc(r.courses, func(c Course) bool { return c.Code == code })
+	if i < 0 {
+		return Course{}, false
+	}
+	return r.courses[i], true
+}
+
+// page returns the window of courses p selects.
+func page(courses []Course, p Page) []Course {
+	start := min(p.Offset, len(courses))
+	end := min(start+p.Limit, len(courses))
+	return courses[start:end]
+}
+
+func (r *MemoryCourseRepository) ListCourses(_ context.Context, f CourseFilter) ([]Course, int, error) {
+	if r.Err != nil {
+		return nil, 0, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	courses := []Course{}
+	for _, c := range r.courses {
+		if f.Department == "" || c.Department == f.Department {
+			courses = append(courses, c)
+		}
+	}
+	slices.SortFunc(courses, func(a, b Course) int { return strings.Compare(a.Code, b.Code) })
+	return page(courses, f.Page), len(courses), nil
+}
+
+func (r *MemoryCourseRepository) UpdateCourse(_ context.Context, c Course, ownerEmails []string) (Course, error) {
+	if r.Err != nil {
+		return c, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.find(c.ID)
+	if i < 0 {
+		return c, ErrCourseNotFound
+	}
+	if r.codeTaken(c.Code, c.ID) {
+		return c, ErrCourseCodeTaken
+	}
+	c.Owners = r.courses[i].Owners
+	if ownerEmails != nil {
+		owners, err := r.owners(ownerEmails)
+		if err != nil {
+			return c, err
+		}
+		c.Owners = owners
+	}
+	c.CreatedAt = r.courses[i].CreatedAt
+	c.UpdatedAt = time.Now()
+	r.courses[i] = c
+	return c, nil
+}
+
+func (r *MemoryCourseRepository) DeleteCourse(_ context.Context, id int) error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.find(id)
+	if i < 0 {
+		return ErrCourseNotFound
+	}
+	r.courses = slices.Delete(r.courses, i, i+1)
+	return nil
+}
+
+// SearchCourses matches Query as a case-insensitive substring of the code
+// or title, ordered by code. It ignores the offering filters and leaves the
+// facets empty.
+func (r *MemoryCourseRepository) SearchCourses(_ context.Context, s CourseSearch) (CourseSearchResult, error) {
+	if r.Err != nil {
+		return CourseSearchResult{}, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	query := strings.ToLower(s.Query)
+	courses := []Course{}
+	for _, c := range r.courses {
+		if s.Department != "" && c.Department != s.Department {
+			continue
+		}
+		if s.Credits != nil && c.Credits != *s.Credits {
+			continue
+		}
+		if !strings.Contains(strings.ToLower(c.Code), query) && !strings.Contains(strings.ToLower(c.Title), query) {
+			continue
+		}
+		courses = append(courses, c)
+	}
+	slices.SortFunc(courses, func(a, b Course) int { return strings.Compare(a.Code, b.Code) })
+	return CourseSearchResult{
+		Courses: page(courses, s.Page),
+		Total:   len(courses),
+		Facets: CourseFacets{
+			Departments: []FacetCount{},
+			Credits:     []FacetCount{},
+			Terms:       []FacetCount{},
+			Instructors: []FacetCount{},
+		},
+	}, nil
+}
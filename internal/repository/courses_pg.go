@@ -0,0 +1,244 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// dbtx is satisfied by both the pool and a transaction, so helpers can run
+// inside or outside one.
+type dbtx interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+const courseColumns = `id, code, title, description, credits, department, created_at, updated_at`
+
+func scanCourse(row pgx.Row) (Course, error) {
+	var c Course
+	err := row.Scan(&c.ID, &c.Code, &c.Title, &c.Description, &c.Credits,
+		&c.Department, &c.CreatedAt, &c.UpdatedAt)
+	return c, err
+}
+
+// courseError translates constraint violations on courses.
+func courseError(err error) error {
+	switch pgCode(err) {
+	case uniqueViolation:
+		return ErrCourseCodeTaken
+	case foreignKeyViolation:
+		return ErrCourseInUse
+	}
+	return err
+}
+
+// loadOwners fills in the owners of each course.
+func loadOwners(ctx context.Context, db dbtx, courses []Course) error {
+	if len(courses) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(courses))
+	index := make(map[int]int, len(courses))
+	for i := range courses {
+		ids[i] = courses[i].ID
+		index[courses[i].ID] = i
+		courses[i].Owners = []CourseOwner{}
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT co.course_id, s.id, s.email, s.first_name, s.last_name
+		FROM course_owners co JOIN staff s ON s.id = co.staff_id
+		WHERE co.course_id = ANY($1)
+		ORDER BY s.email`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var (
+			courseID int
+			o        CourseOwner
+		)
+		if err := rows.Scan(&courseID, &o.StaffID, &o.Email, &o.FirstName, &o.LastName); err != nil {
+			return err
+		}
+		c := &courses[index[courseID]]
+		c.Owners = append(c.Owners, o)
+	}
+	return rows.Err()
+}
+
+// staffIDs returns the IDs of the staff with the given emails, in order,
+// or ErrStaffNotFound naming the first unknown email.
+func staffIDs(ctx context.Context, db dbtx, emails []string) ([]int, error) {
+	rows, err := db.Query(ctx, `SELECT id, email FROM staff WHERE email = ANY($1)`, emails)
+	if err != nil {
+		return nil, err
+	}
+	found := make(map[string]int)
+	for rows.Next() {
+		var (
+			id    int
+			email string
+		)
+		if err := rows.Scan(&id, &email); err != nil {
+			rows.Close()
+			return nil, err
+		}
+		found[email] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	ids := make([]int, 0, len(emails))
+	for _, email := range emails {
+		id, ok := found[email]
+		if !ok {
+			return nil, fmt.Errorf("%w: %s", ErrStaffNotFound, email)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// setOwners replaces the owners of a course with the staff in emails.
+func setOwners(ctx context.Context, tx pgx.Tx, courseID int, emails []string) error {
+	ids, err := staffIDs(ctx, tx, emails)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM course_owners WHERE course_id=$1`, courseID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO course_owners (course_id, staff_id)
+		SELECT $1, unnest($2::int[])
+		ON CONFLICT DO NOTHING`,
+		courseID, ids)
+	return err
+}
+
+// PgCourseRepository stores the course catalog in Postgres.
+type PgCourseRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgCourseRepository(db *pgxpool.Pool) *PgCourseRepository {
+	return &PgCourseRepository{db: db}
+}
+
+func (r *PgCourseRepository) CreateCourse(ctx context.Context, c Course, ownerEmails []string) (Course, error) {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		c, err = scanCourse(tx.QueryRow(ctx, `
+			INSERT INTO courses (code, title, description, credits, department)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING `+courseColumns,
+			c.Code, c.Title, c.Description, c.Credits, c.Department))
+		if err != nil {
+			return courseError(err)
+		}
+		if err := setOwners(ctx, tx, c.ID, ownerEmails); err != nil {
+			return err
+		}
+		return r.withOwners(ctx, tx, &c)
+	})
+	return c, classify(err)
+}
+
+func (r *PgCourseRepository) GetCourse(ctx context.Context, id int) (Course, error) {
+	c, err := scanCourse(r.db.QueryRow(ctx,
+		`SELECT `+courseColumns+` FROM courses WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return c, ErrCourseNotFound
+	}
+	if err != nil {
+		return c, classify(err)
+	}
+	return c, classify(r.withOwners(ctx, r.db, &c))
+}
+
+func (r *PgCourseRepository) ListCourses(ctx context.Context, f CourseFilter) ([]Course, int, error) {
+	var total int
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(*) FROM courses WHERE ($1 = '' OR department = $1)`,
+		f.Department,
+	).Scan(&total)
+	if err != nil {
+		return nil, 0, classify(err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT `+courseColumns+` FROM courses
+		WHERE ($1 = '' OR department = $1)
+		ORDER BY code
+		LIMIT $2 OFFSET $3`,
+		f.Department, f.Page.Limit, f.Page.Offset)
+	if err != nil {
+		return nil, 0, classify(err)
+	}
+	courses, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Course, error) {
+		return scanCourse(row)
+	})
+	if err != nil {
+		return nil, 0, classify(err)
+	}
+
+	return courses, total, classify(loadOwners(ctx, r.db, courses))
+}
+
+func (r *PgCourseRepository) UpdateCourse(ctx context.Context, c Course, ownerEmails []string) (Course, error) {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		c, err = scanCourse(tx.QueryRow(ctx, `
+			UPDATE courses
+			SET code=$2, title=$3, description=$4, credits=$5, department=$6, updated_at=NOW()
+			WHERE id=$1
+			RETURNING `+courseColumns,
+			c.ID, c.Code, c.Title, c.Description, c.Credits, c.Department))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrCourseNotFound
+		}
+		if err != nil {
+			return courseError(err)
+		}
+		if ownerEmails != nil {
+			if err := setOwners(ctx, tx, c.ID, ownerEmails); err != nil {
+				return err
+			}
+		}
+		return r.withOwners(ctx, tx, &c)
+	})
+	return c, classify(err)
+}
+
+func (r *PgCourseRepository) DeleteCourse(ctx context.Context, id int) error {
+	tag, err := r.db.Exec(ctx, `DELETE FROM courses WHERE id=$1`, id)
+	if err != nil {
+		return classify(courseError(err))
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrCourseNotFound
+	}
+	return nil
+}
+
+func (r *PgCourseRepository) withOwners(ctx context.Context, db dbtx, c *Course) error {
+	courses := []Course{*c}
+	if err := loadOwners(ctx, db, courses); err != nil {
+		return err
+	}
+	*c = courses[0]
+	return nil
+}
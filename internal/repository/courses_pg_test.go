@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"elimu-go/internal/testdb"
+)
+
+func newCourseRepo(t *testing.T) *PgCourseRepository {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO staff (first_name, last_name, email, role) VALUES
+			('Ruth', 'Njeri', 'ruth@school.edu', 'teacher'),
+			('Otieno', 'Ouma', 'otieno@school.edu', 'teacher')`)
+	return NewPgCourseRepository(pool)
+}
+
+func TestPgCourseRepository_CRUD(t *testing.T) {
+	courses := newCourseRepo(t)
+	ctx := context.Background()
+
+	c, err := courses.CreateCourse(ctx, Course{
+		Code: "CS101", Title: "Intro to Programming", Credits: 3, Department: "CS",
+	}, []string{"ruth@school.edu"})
+	if err != nil {
+		t.Fatalf("CreateCourse: %v", err)
+	}
+	if !c.IsOwner("ruth@school.edu") || len(c.Owners) != 1 {
+		t.Errorf("Expected ruth as the only owner, got %+v", c.Owners)
+	}
+
+	if _, err := courses.CreateCourse(ctx, Course{Code: "CS101", Title: "Again", Credits: 3, Department: "CS"},
+		[]string{"ruth@school.edu"}); !errors.Is(err, ErrCourseCodeTaken) {
+		t.Errorf("Expected ErrCourseCodeTaken for a duplicate code, got %v", err)
+	}
+	if _, err := courses.CreateCourse(ctx, Course{Code: "CS102", Title: "Data", Credits: 3, Department: "CS"},
+		[]string{"nobody@school.edu"}); !errors.Is(err, ErrStaffNotFound) {
+		t.Errorf("Expected ErrStaffNotFound for an unknown owner, got %v", err)
+	}
+
+	c.Title = "Introduction to Programming"
+	updated, err := courses.UpdateCourse(ctx, c, nil)
+	if err != nil {
+		t.Fatalf("UpdateCourse: %v", err)
+	}
+	if updated.Title != c.Title || !updated.IsOwner("ruth@school.edu") {
+		t.Errorf("Expected the title to change and the owners to stay, got %+v", updated)
+	}
+
+	updated, err = courses.UpdateCourse(ctx, c, []string{"otieno@school.edu"})
+	if err != nil {
+		t.Fatalf("UpdateCourse: %v", err)
+	}
+	if updated.IsOwner("ruth@school.edu") || !updated.IsOwner("otieno@school.edu") {
+		t.Errorf("Expected otieno to replace ruth, got %+v", updated.Owners)
+	}
+
+	if err := courses.DeleteCourse(ctx, c.ID); err != nil {
+		t.Fatalf("DeleteCourse: %v", err)
+	}
+	if _, err := courses.GetCourse(ctx, c.ID); !errors.Is(err, ErrCourseNotFound) {
+		t.Errorf("Expected ErrCourseNotFound after delete, got %v", err)
+	}
+}
+
+func TestPgCourseRepository_List(t *testing.T) {
+	courses := newCourseRepo(t)
+	ctx := context.Background()
+
+	for _, c := range []Course{
+		{Code: "MATH201", Title: "Linear Algebra", Credits: 4, Department: "Mathematics"},
+		{Code: "CS201", Title: "Algorithms", Credits: 4, Department: "CS"},
+		{Code: "CS101", Title: "Intro to Programming", Credits: 3, Department: "CS"},
+	} {
+		if _, err := courses.CreateCourse(ctx, c, []string{"ruth@school.edu"}); err != nil {
+			t.Fatalf("CreateCourse %s: %v", c.Code, err)
+		}
+	}
+
+	list, total, err := courses.ListCourses(ctx, CourseFilter{Department: "CS", Page: Page{Limit: 1}})
+	if err != nil {
+		t.Fatalf("ListCourses: %v", err)
+	}
+	if total != 2 || len(list) != 1 || list[0].Code != "CS101" {
+		t.Errorf("Expected CS101 of 2 CS courses, got %d %+v", total, list)
+	}
+	if len(list[0].Owners) != 1 {
+		t.Errorf("Expected owners to be loaded, got %+v", list[0].Owners)
+	}
+}
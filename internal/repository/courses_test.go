@@ -0,0 +1,17 @@
+package repository
+
+import "testing"
+
+func TestCourse_IsOwner(t *testing.T) {
+	c := Course{Owners: []CourseOwner{{Email: "ruth@school.edu"}}}
+
+	for email, want := range map[string]bool{
+		"ruth@school.edu":  true,
+		"Ruth@School.EDU":  true,
+		"grace@school.edu": false,
+	} {
+		if got := c.IsOwner(email); got != want {
+			t.Errorf("IsOwner(%q) = %v, want %v", email, got, want)
+		}
+	}
+}
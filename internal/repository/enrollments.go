@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEnrollmentNotFound = fmt.Errorf("enrollment %w", ErrNotFound)
+	ErrAlreadyEnrolled    = fmt.Errorf("%w: student is already enrolled or waitlisted", ErrConflict)
+	ErrOfferingFull       = fmt.Errorf("%w: offering is full", ErrConflict)
+	ErrAddDropClosed      = fmt.Errorf("%w: the add/drop deadline has passed", ErrConflict)
+)
+
+// Enrollment statuses.
+const (
+	Enrolled   = "enrolled"
+	Waitlisted = "waitlisted"
+	Dropped    = "dropped"
+)
+
+// Enrollment is a student's place in, or on the waitlist of, an offering.
+type Enrollment struct {
+	ID           int    `json:"id"`
+	OfferingID   int    `json:"offering_id"`
+	TermID       int    `json:"term_id"`
+	CourseCode   string `json:"course_code"`
+	Section      string `json:"section"`
+	StudentEmail string `json:"student_email"`
+	Status       string `json:"status"`
+
+	// WaitlistPosition counts from 1 and is only set while waitlisted.
+	WaitlistPosition int `json:"waitlist_position,omitempty"`
+
+	RequestedAt time.Time  `json:"requested_at"`
+	EnrolledAt  *time.Time `json:"enrolled_at"`
+	DroppedAt   *time.Time `json:"dropped_at"`
+}
+
+// DropResult is a dropped enrollment and the waitlisted students promoted
+// into the seat it freed.
+type DropResult struct {
+	Dropped  Enrollment
+	Promoted []Enrollment
+}
+
+// EnrollmentRepository enrolls students in course offerings.
+type EnrollmentRepository interface {
+	// Enroll gives the student a seat in the offering. When the offering
+	// is full, or others are already waiting, the student joins the
+	// waitlist if waitlist is set and gets ErrOfferingFull otherwise.
+	// Outside the student's enrollment window it returns
+	// ErrEnrollmentNotOpen or ErrEnrollmentClosed unless they hold an
+	// override. An offering meeting at the same time as another the
+	// student holds a place in that term gets a ClashError.
+	Enroll(ctx context.Context, offeringID int, studentEmail string, waitlist bool) (Enrollment, error)
+
+	// Drop ends the student's enrollment or waitlist place and promotes
+	// waitlisted students into any seat that frees up, notifying them.
+	// After the term's add/drop deadline enrolled students get
+	// ErrAddDropClosed; waitlist places may still be given up.
+	Drop(ctx context.Context, offeringID int, studentEmail string) (DropResult, error)
+
+	// StudentEnrollments lists the student's live enrollments and waitlist
+	// places, optionally in one term only.
+	StudentEnrollments(ctx context.Context, studentEmail string, termID int) ([]Enrollment, error)
+
+	// Timetable lists the weekly meetings of the offerings someone takes
+	// or teaches in a term, by weekday and time. A zero termID means the
+	// term in progress today.
+	Timetable(ctx context.Context, email string, termID int) ([]TimetableEntry, error)
+}
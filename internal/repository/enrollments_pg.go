@@ -0,0 +1,273 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const enrollmentSelect = `
+	SELECT e.id, e.offering_id, o.term_id, c.code, o.section, s.email, e.status,
+		CASE WHEN e.status = 'waitlisted' THEN (
+			SELECT COUNT(*) FROM enrollments w
+			WHERE w.offering_id = e.offering_id AND w.status = 'waitlisted'
+				AND (w.requested_at, w.id) <= (e.requested_at, e.id))
+		ELSE 0 END,
+		e.requested_at, e.enrolled_at, e.dropped_at
+	FROM enrollments e
+	JOIN course_offerings o ON o.id = e.offering_id
+	JOIN courses c ON c.id = o.course_id
+	JOIN students s ON s.id = e.student_id`
+
+func scanEnrollment(row pgx.Row) (Enrollment, error) {
+	var e Enrollment
+	err := row.Scan(&e.ID, &e.OfferingID, &e.TermID, &e.CourseCode, &e.Section, &e.StudentEmail,
+		&e.Status, &e.WaitlistPosition, &e.RequestedAt, &e.EnrolledAt, &e.DroppedAt)
+	return e, err
+}
+
+func enrollmentsByID(ctx context.Context, db dbtx, ids []int) ([]Enrollment, error) {
+	rows, err := db.Query(ctx, enrollmentSelect+` WHERE e.id = ANY($1) ORDER BY e.id`, ids)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (Enrollment, error) {
+		return scanEnrollment(row)
+	})
+}
+
+func studentIDByEmail(ctx context.Context, db dbtx, email string) (int, error) {
+	var id int
+	err := db.QueryRow(ctx, `SELECT id FROM students WHERE email=$1`, email).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrStudentNotFound
+	}
+	return id, err
+}
+
+// lockOffering takes the offering's row lock, which every change to its
+// seats holds so concurrent requests cannot overbook it. It returns the
+// offering's capacity.
+func lockOffering(ctx context.Context, tx pgx.Tx, offeringID int) (int, error) {
+	var capacity int
+	err := tx.QueryRow(ctx, `SELECT capacity FROM course_offerings WHERE id=$1 FOR UPDATE`, offeringID).Scan(&capacity)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, ErrOfferingNotFound
+	}
+	return capacity, err
+}
+
+// promoteWaitlist moves waitlisted students into the offering's free
+// seats, earliest request first, and notifies them. The caller must hold
+// the offering's row lock.
+func promoteWaitlist(ctx context.Context, tx pgx.Tx, offeringID int) ([]Enrollment, error) {
+	rows, err := tx.Query(ctx, `
+		UPDATE enrollments e
+		SET status = 'enrolled', enrolled_at = NOW()
+		WHERE e.id IN (
+			SELECT id FROM enrollments
+			WHERE offering_id = $1 AND status = 'waitlisted'
+			ORDER BY requested_at, id
+			LIMIT (SELECT seats_open FROM offering_seats WHERE offering_id = $1))
+		RETURNING e.id`, offeringID)
+	if err != nil {
+		return nil, err
+	}
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[int])
+	if err != nil || len(ids) == 0 {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `
+		INSERT INTO notifications (recipient, kind, message, offering_id)
+		SELECT s.email, $2::text, format('A seat opened in %s section %s and you have been enrolled from the waitlist.', c.code, o.section), o.id
+		FROM enrollments e
+		JOIN students s ON s.id = e.student_id
+		JOIN course_offerings o ON o.id = e.offering_id
+		JOIN courses c ON c.id = o.course_id
+		WHERE e.id = ANY($1)`,
+		ids, NotificationWaitlistPromoted)
+	if err != nil {
+		return nil, err
+	}
+
+	return enrollmentsByID(ctx, tx, ids)
+}
+
+// PgEnrollmentRepository stores enrollments in Postgres.
+type PgEnrollmentRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgEnrollmentRepository(db *pgxpool.Pool) *PgEnrollmentRepository {
+	return &PgEnrollmentRepository{db: db}
+}
+
+func (r *PgEnrollmentRepository) Enroll(ctx context.Context, offeringID int, studentEmail string, waitlist bool) (Enrollment, error) {
+	var e Enrollment
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		capacity, err := lockOffering(ctx, tx, offeringID)
+		if err != nil {
+			return err
+		}
+		studentID, err := studentIDByEmail(ctx, tx, studentEmail)
+		if err != nil {
+			return err
+		}
+		if err := checkEnrollmentWindow(ctx, tx, offeringID, studentID); err != nil {
+			return err
+		}
+
+		// Holding the student's row lock keeps two enrollments of theirs
+		// from passing the clash check at once.
+		if _, err := tx.Exec(ctx, `SELECT 1 FROM students WHERE id=$1 FOR NO KEY UPDATE`, studentID); err != nil {
+			return err
+		}
+		if err := checkStudentClashes(ctx, tx, offeringID, studentID); err != nil {
+			return err
+		}
+
+		var enrolled, waiting int
+		err = tx.QueryRow(ctx, `
+			SELECT COUNT(*) FILTER (WHERE status = 'enrolled'),
+				COUNT(*) FILTER (WHERE status = 'waitlisted')
+			FROM enrollments WHERE offering_id=$1`,
+			offeringID,
+		).Scan(&enrolled, &waiting)
+		if err != nil {
+			return err
+		}
+
+		// Newcomers queue behind anyone already waiting.
+		status := Enrolled
+		if enrolled >= capacity || waiting > 0 {
+			if !waitlist {
+				return ErrOfferingFull
+			}
+			status = Waitlisted
+		}
+
+		var id int
+		err = tx.QueryRow(ctx, `
+			INSERT INTO enrollments (offering_id, student_id, status, enrolled_at)
+			VALUES ($1, $2, $3::text, CASE WHEN $3::text = 'enrolled' THEN NOW() END)
+			RETURNING id`,
+			offeringID, studentID, status,
+		).Scan(&id)
+		if pgCode(err) == uniqueViolation {
+			return ErrAlreadyEnrolled
+		}
+		if err != nil {
+			return err
+		}
+
+		e, err = scanEnrollment(tx.QueryRow(ctx, enrollmentSelect+` WHERE e.id=$1`, id))
+		return err
+	})
+	return e, classify(err)
+}
+
+func (r *PgEnrollmentRepository) Drop(ctx context.Context, offeringID int, studentEmail string) (DropResult, error) {
+	var result DropResult
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := lockOffering(ctx, tx, offeringID); err != nil {
+			return err
+		}
+
+		var (
+			id       int
+			status   string
+			deadline time.Time
+			now      time.Time
+		)
+		err := tx.QueryRow(ctx, `
+			SELECT e.id, e.status, t.add_drop_deadline, NOW()
+			FROM enrollments e
+			JOIN students s ON s.id = e.student_id
+			JOIN course_offerings o ON o.id = e.offering_id
+			JOIN terms t ON t.id = o.term_id
+			WHERE e.offering_id = $1 AND s.email = $2 AND e.status <> 'dropped'`,
+			offeringID, studentEmail,
+		).Scan(&id, &status, &deadline, &now)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrEnrollmentNotFound
+		}
+		if err != nil {
+			return err
+		}
+		// Leaving the waitlist frees no seat, so it is allowed at any time.
+		if status == Enrolled && !now.Before(deadline) {
+			return fmt.Errorf("%w: drops closed at %s; ask the registrar to remove you from the roster",
+				ErrAddDropClosed, deadline.UTC().Format(time.RFC3339))
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE enrollments SET status = 'dropped', dropped_at = NOW() WHERE id=$1`, id)
+		if err != nil {
+			return err
+		}
+
+		result.Dropped, err = scanEnrollment(tx.QueryRow(ctx, enrollmentSelect+` WHERE e.id=$1`, id))
+		if err != nil {
+			return err
+		}
+		result.Promoted, err = promoteWaitlist(ctx, tx, offeringID)
+		return err
+	})
+	return result, classify(err)
+}
+
+func (r *PgEnrollmentRepository) StudentEnrollments(ctx context.Context, studentEmail string, termID int) ([]Enrollment, error) {
+	rows, err := r.db.Query(ctx, enrollmentSelect+`
+		WHERE s.email = $1 AND e.status <> 'dropped' AND ($2::int = 0 OR o.term_id = $2::int)
+		ORDER BY o.term_id DESC, c.code, o.section`,
+		studentEmail, termID)
+	if err != nil {
+		return nil, classify(err)
+	}
+	enrollments, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Enrollment, error) {
+		return scanEnrollment(row)
+	})
+	return enrollments, classify(err)
+}
+
+func (r *PgEnrollmentRepository) Timetable(ctx context.Context, email string, termID int) ([]TimetableEntry, error) {
+	if termID != 0 {
+		if err := termExists(ctx, r.db, termID); err != nil {
+			return nil, classify(err)
+		}
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT o.id, c.code, o.section, m.weekday, to_char(m.starts_at, 'HH24:MI'),
+			to_char(m.ends_at, 'HH24:MI'), m.room, c.title, x.role, x.status
+		FROM (
+			SELECT e.offering_id, 'student'::text AS role, e.status::text AS status
+			FROM enrollments e JOIN students s ON s.id = e.student_id
+			WHERE s.email = $1 AND e.status <> 'dropped'
+			UNION ALL
+			SELECT oi.offering_id, 'instructor'::text, ''::text
+			FROM offering_instructors oi JOIN staff st ON st.id = oi.staff_id
+			WHERE st.email = $1
+		) x
+		JOIN course_offerings o ON o.id = x.offering_id
+		JOIN courses c ON c.id = o.course_id
+		JOIN terms t ON t.id = o.term_id
+		JOIN offering_meetings m ON m.offering_id = o.id
+		WHERE CASE WHEN $2::int = 0 THEN CURRENT_DATE BETWEEN t.starts_on AND t.ends_on
+			ELSE o.term_id = $2::int END
+		ORDER BY m.weekday, m.starts_at, c.code, o.section`,
+		email, termID)
+	if err != nil {
+		return nil, classify(err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (TimetableEntry, error) {
+		var e TimetableEntry
+		err := row.Scan(&e.OfferingID, &e.CourseCode, &e.Section, &e.Weekday, &e.StartsAt,
+			&e.EndsAt, &e.Room, &e.CourseTitle, &e.Role, &e.Status)
+		return e, err
+	})
+	return entries, classify(err)
+}
@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgEnrollmentRepository_WaitlistPromotion(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	terms := NewPgTermRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	notifications := NewPgNotificationRepository(pool)
+	ctx := context.Background()
+
+	offering := createOffering(t, terms, pool, "CS101", 1)
+
+	first, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false)
+	if err != nil {
+		t.Fatalf("Enroll amani: %v", err)
+	}
+	if first.Status != Enrolled || first.EnrolledAt == nil {
+		t.Errorf("Expected amani to get the seat, got %+v", first)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", true); !errors.Is(err, ErrAlreadyEnrolled) {
+		t.Errorf("Expected ErrAlreadyEnrolled, got %v", err)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "baraka@student.school.edu", false); !errors.Is(err, ErrOfferingFull) {
+		t.Errorf("Expected ErrOfferingFull without waitlisting, got %v", err)
+	}
+
+	for i, email := range []string{"baraka@student.school.edu", "chege@student.school.edu"} {
+		e, err := enrollments.Enroll(ctx, offering.ID, email, true)
+		if err != nil {
+			t.Fatalf("Enroll %s: %v", email, err)
+		}
+		if e.Status != Waitlisted || e.WaitlistPosition != i+1 {
+			t.Errorf("Expected %s waitlisted at %d, got %+v", email, i+1, e)
+		}
+	}
+
+	result, err := enrollments.Drop(ctx, offering.ID, "amani@student.school.edu")
+	if err != nil {
+		t.Fatalf("Drop: %v", err)
+	}
+	if result.Dropped.Status != Dropped {
+		t.Errorf("Expected amani dropped, got %+v", result.Dropped)
+	}
+	if len(result.Promoted) != 1 || result.Promoted[0].StudentEmail != "baraka@student.school.edu" {
+		t.Fatalf("Expected baraka promoted, got %+v", result.Promoted)
+	}
+	if _, err := enrollments.Drop(ctx, offering.ID, "amani@student.school.edu"); !errors.Is(err, ErrEnrollmentNotFound) {
+		t.Errorf("Expected ErrEnrollmentNotFound for a second drop, got %v", err)
+	}
+
+	inbox, err := notifications.ListNotifications(ctx, "baraka@student.school.edu", true)
+	if err != nil {
+		t.Fatalf("ListNotifications: %v", err)
+	}
+	if len(inbox) != 1 || inbox[0].Kind != NotificationWaitlistPromoted {
+		t.Fatalf("Expected a promotion notification, got %+v", inbox)
+	}
+	if err := notifications.MarkRead(ctx, "chege@student.school.edu", inbox[0].ID); !errors.Is(err, ErrNotificationNotFound) {
+		t.Errorf("Expected another student's notification to be hidden, got %v", err)
+	}
+	if err := notifications.MarkRead(ctx, "baraka@student.school.edu", inbox[0].ID); err != nil {
+		t.Fatalf("MarkRead: %v", err)
+	}
+
+	mine, err := enrollments.StudentEnrollments(ctx, "chege@student.school.edu", offering.TermID)
+	if err != nil {
+		t.Fatalf("StudentEnrollments: %v", err)
+	}
+	if len(mine) != 1 || mine[0].WaitlistPosition != 1 {
+		t.Errorf("Expected chege first on the waitlist, got %+v", mine)
+	}
+
+	// Raising the capacity frees a seat for the rest of the waitlist.
+	offering.Capacity = 2
+	if _, err := terms.UpdateOffering(ctx, offering, nil); err != nil {
+		t.Fatalf("UpdateOffering: %v", err)
+	}
+	mine, _ = enrollments.StudentEnrollments(ctx, "chege@student.school.edu", offering.TermID)
+	if len(mine) != 1 || mine[0].Status != Enrolled {
+		t.Errorf("Expected chege promoted after the capacity grew, got %+v", mine)
+	}
+}
+
+func TestPgEnrollmentRepository_DropAfterDeadline(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	terms := NewPgTermRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	ctx := context.Background()
+
+	offering := createOffering(t, terms, pool, "CS101", 1)
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false); err != nil {
+		t.Fatalf("Enroll amani: %v", err)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "baraka@student.school.edu", true); err != nil {
+		t.Fatalf("Enroll baraka: %v", err)
+	}
+
+	testdb.Exec(t, pool, `UPDATE terms SET add_drop_deadline = NOW() - INTERVAL '1 hour' WHERE id=$1`, offering.TermID)
+
+	if _, err := enrollments.Drop(ctx, offering.ID, "amani@student.school.edu"); !errors.Is(err, ErrAddDropClosed) {
+		t.Errorf("Expected ErrAddDropClosed dropping after the deadline, got %v", err)
+	}
+	mine, _ := enrollments.StudentEnrollments(ctx, "amani@student.school.edu", offering.TermID)
+	if len(mine) != 1 || mine[0].Status != Enrolled {
+		t.Errorf("Expected amani to keep the seat, got %+v", mine)
+	}
+
+	result, err := enrollments.Drop(ctx, offering.ID, "baraka@student.school.edu")
+	if err != nil {
+		t.Fatalf("Expected a waitlist place to be given up after the deadline, got %v", err)
+	}
+	if result.Dropped.Status != Dropped || len(result.Promoted) != 0 {
+		t.Errorf("Expected baraka to leave the waitlist with no promotion, got %+v", result)
+	}
+}
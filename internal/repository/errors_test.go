@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+func TestClassify(t *testing.T) {
+	if classify(nil) != nil {
+		t.Error("nil should stay nil")
+	}
+
+	if !errors.Is(classify(pgx.ErrNoRows), ErrNotFound) {
+		t.Error("ErrNoRows should become ErrNotFound")
+	}
+
+	for _, err := range []error{
+		context.DeadlineExceeded,
+		fmt.Errorf("query: %w", context.Canceled),
+		&pgconn.PgError{Code: "08006"},
+		&pgconn.PgError{Code: "57P01"},
+	} {
+		if !IsUnavailable(classify(err)) {
+			t.Errorf("%v should be classified as unavailable", err)
+		}
+	}
+
+	syntax := &pgconn.PgError{Code: "42601"}
+	if got := classify(syntax); got != syntax {
+		t.Errorf("Query errors should pass through unchanged, got %v", got)
+	}
+
+	if got := classify(ErrStaffNotFound); got != ErrStaffNotFound {
+		t.Errorf("Repository errors should pass through unchanged, got %v", got)
+	}
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"elimu-go/internal/testdb"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func courseIDByCode(t *testing.T, pool *pgxpool.Pool, code string) int {
+	t.Helper()
+
+	var id int
+	if err := pool.QueryRow(context.Background(), `SELECT id FROM courses WHERE code=$1`, code).Scan(&id); err != nil {
+		t.Fatalf("lookup course %s: %v", code, err)
+	}
+	return id
+}
+
+func createTerm(t *testing.T, terms *PgTermRepository, code string, start time.Time) Term {
+	t.Helper()
+
+	term, err := terms.CreateTerm(context.Background(), Term{
+		Code:               code,
+		Name:               code,
+		StartsOn:           start,
+		EndsOn:             start.AddDate(0, 4, 0),
+		EnrollmentOpensAt:  start.AddDate(0, -1, 0),
+		EnrollmentClosesAt: start.AddDate(0, 0, 14),
+		AddDropDeadline:    start.AddDate(0, 0, 14),
+	})
+	if err != nil {
+		t.Fatalf("CreateTerm %s: %v", code, err)
+	}
+	return term
+}
+
+// seedStudents adds three students and the CS101 course they enroll in.
+func seedStudents(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	testdb.Exec(t, pool, `
+		INSERT INTO students (first_name, last_name, email) VALUES
+			('Amani', 'Wanjiru', 'amani@student.school.edu'),
+			('Baraka', 'Otieno', 'baraka@student.school.edu'),
+			('Chege', 'Kamau', 'chege@student.school.edu');
+		INSERT INTO courses (code, title, credits, department) VALUES
+			('CS101', 'Intro to Programming', 3, 'CS')`)
+}
+
+// createOffering adds section A of a course to a term starting in a week.
+// Enrollment for the term is open from a month before it starts until two
+// weeks in.
+func createOffering(t *testing.T, terms *PgTermRepository, pool *pgxpool.Pool, code string, capacity int) Offering {
+	t.Helper()
+
+	term := createTerm(t, terms, "NOW", time.Now().AddDate(0, 0, 7))
+	offering, err := terms.CreateOffering(context.Background(), Offering{
+		TermID:   term.ID,
+		CourseID: courseIDByCode(t, pool, code),
+		Section:  "A",
+		Capacity: capacity,
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOffering %s: %v", code, err)
+	}
+	return offering
+}
@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var ErrNotificationNotFound = fmt.Errorf("notification %w", ErrNotFound)
+
+// Notification kinds.
+const (
+	NotificationWaitlistPromoted = "waitlist_promoted"
+)
+
+// Notification is an in-app message to a user.
+type Notification struct {
+	ID         int        `json:"id"`
+	Kind       string     `json:"kind"`
+	Message    string     `json:"message"`
+	OfferingID *int       `json:"offering_id"`
+	CreatedAt  time.Time  `json:"created_at"`
+	ReadAt     *time.Time `json:"read_at"`
+}
+
+// NotificationRepository reads and acknowledges in-app notifications.
+// Notifications are written by the repositories whose changes cause them,
+// in the same transaction.
+type NotificationRepository interface {
+	// ListNotifications returns the recipient's notifications, newest
+	// first.
+	ListNotifications(ctx context.Context, recipient string, unreadOnly bool) ([]Notification, error)
+
+	// MarkRead marks one of the recipient's notifications as read.
+	MarkRead(ctx context.Context, recipient string, id int) error
+}
@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgNotificationRepository reads notifications from Postgres.
+type PgNotificationRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgNotificationRepository(db *pgxpool.Pool) *PgNotificationRepository {
+	return &PgNotificationRepository{db: db}
+}
+
+func (r *PgNotificationRepository) ListNotifications(ctx context.Context, recipient string, unreadOnly bool) ([]Notification, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT id, kind, message, offering_id, created_at, read_at
+		FROM notifications
+		WHERE recipient=$1 AND (NOT $2::bool OR read_at IS NULL)
+		ORDER BY created_at DESC, id DESC`,
+		recipient, unreadOnly)
+	if err != nil {
+		return nil, classify(err)
+	}
+	notifications, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Notification, error) {
+		var n Notification
+		err := row.Scan(&n.ID, &n.Kind, &n.Message, &n.OfferingID, &n.CreatedAt, &n.ReadAt)
+		return n, err
+	})
+	return notifications, classify(err)
+}
+
+func (r *PgNotificationRepository) MarkRead(ctx context.Context, recipient string, id int) error {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE notifications SET read_at = COALESCE(read_at, NOW())
+		WHERE id=$1 AND recipient=$2`,
+		id, recipient)
+	if err != nil {
+		return classify(err)
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotificationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,7 @@
+package repository
+
+// Page selects a window of a list ordered by the repository.
+type Page struct {
+	Limit  int
+	Offset int
+}
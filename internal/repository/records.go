@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var ErrStudentNotFound = fmt.Errorf("student %w", ErrNotFound)
+
+// gradeScale lists the letter grades from best to worst. F is the only
+// failing grade.
+var gradeScale = []string{"A", "A-", "B+", "B", "B-", "C+", "C", "C-", "D+", "D", "D-", "F"}
+
+const failingGrade = "F"
+
+// ValidGrade reports whether g is a letter grade on the scale.
+func ValidGrade(g string) bool {
+	return slices.Contains(gradeScale, g)
+}
+
+// GradeAtLeast reports whether g is a pass and no worse than min. An empty
+// min accepts any pass.
+func GradeAtLeast(g, min string) bool {
+	rank := slices.Index(gradeScale, g)
+	if rank < 0 || g == failingGrade {
+		return false
+	}
+	if min == "" {
+		return true
+	}
+	return rank <= slices.Index(gradeScale, min)
+}
+
+// AcademicRecord is one attempt of a course by a student.
+type AcademicRecord struct {
+	CourseID int    `json:"course_id"`
+	Code     string `json:"code"`
+
+	// Grade is nil while the course is in progress.
+	Grade      *string   `json:"grade"`
+	RecordedAt time.Time `json:"recorded_at"`
+}
+
+// RecordRepository reads and adds to students' academic records.
+type RecordRepository interface {
+	// StudentRecord returns every course attempt of the student with the
+	// given email, or ErrStudentNotFound. Live enrollments in terms that
+	// have not ended count as attempts in progress.
+	StudentRecord(ctx context.Context, email string) ([]AcademicRecord, error)
+
+	// RecordGrade adds a graded attempt of the course to the student's
+	// record, returning ErrStudentNotFound or ErrCourseNotFound.
+	RecordGrade(ctx context.Context, email string, courseID int, grade string) (AcademicRecord, error)
+}
@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRecordRepository is an in-memory RecordRepository for tests. Its
+// students are those of the MemoryUserRepository and its courses those of
+// the MemoryCourseRepository it was created with. It keeps no enrollments,
+// so records hold only the grades recorded through it.
+type MemoryRecordRepository struct {
+	mu      sync.RWMutex
+	users   *MemoryUserRepository
+	courses *MemoryCourseRepository
+	records map[string][]AcademicRecord
+
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func NewMemoryRecordRepository(users *MemoryUserRepository, courses *MemoryCourseRepository) *MemoryRecordRepository {
+	return &MemoryRecordRepository{users: users, courses: courses, records: make(map[string][]AcademicRecord)}
+}
+
+func (r *MemoryRecordRepository) StudentRecord(_ context.Context, email string) ([]AcademicRecord, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	if !r.users.isStudent(email) {
+		return nil, ErrStudentNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]AcademicRecord{}, r.records[email]...), nil
+}
+
+func (r *MemoryRecordRepository) RecordGrade(_ context.Context, email string, courseID int, grade string) (AcademicRecord, error) {
+	rec := AcademicRecord{CourseID: courseID, Grade: &grade}
+	if r.Err != nil {
+		return rec, r.Err
+	}
+	if !r.users.isStudent(email) {
+		return rec, ErrStudentNotFound
+	}
+	c, ok := r.courses.course(courseID)
+	if !ok {
+		return rec, ErrCourseNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rec.Code = c.Code
+	rec.RecordedAt = time.Now()
+	r.records[email] = append(r.records[email], rec)
+	return rec, nil
+}
@@ -0,0 +1,71 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgRecordRepository reads academic records from Postgres.
+type PgRecordRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRecordRepository(db *pgxpool.Pool) *PgRecordRepository {
+	return &PgRecordRepository{db: db}
+}
+
+func (r *PgRecordRepository) StudentRecord(ctx context.Context, email string) ([]AcademicRecord, error) {
+	studentID, err := studentIDByEmail(ctx, r.db, email)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	rows, err := r.db.Query(ctx, `
+		SELECT x.course_id, c.code, x.grade, x.recorded_at
+		FROM (
+			SELECT course_id, grade, recorded_at
+			FROM academic_records
+			WHERE student_id = $1
+			UNION ALL
+			SELECT o.course_id, NULL, e.enrolled_at
+			FROM enrollments e
+			JOIN course_offerings o ON o.id = e.offering_id
+			JOIN terms t ON t.id = o.term_id
+			WHERE e.student_id = $1 AND e.status = 'enrolled' AND t.ends_on >= CURRENT_DATE
+		) x JOIN courses c ON c.id = x.course_id
+		ORDER BY x.recorded_at`, studentID)
+	if err != nil {
+		return nil, classify(err)
+	}
+	record, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (AcademicRecord, error) {
+		var rec AcademicRecord
+		err := row.Scan(&rec.CourseID, &rec.Code, &rec.Grade, &rec.RecordedAt)
+		return rec, err
+	})
+	return record, classify(err)
+}
+
+func (r *PgRecordRepository) RecordGrade(ctx context.Context, email string, courseID int, grade string) (AcademicRecord, error) {
+	rec := AcademicRecord{CourseID: courseID, Grade: &grade}
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		studentID, err := studentIDByEmail(ctx, tx, email)
+		if err != nil {
+			return err
+		}
+		if err := courseExists(ctx, tx, courseID); err != nil {
+			return err
+		}
+		return tx.QueryRow(ctx, `
+			WITH inserted AS (
+				INSERT INTO academic_records (student_id, course_id, grade)
+				VALUES ($1, $2, $3)
+				RETURNING course_id, recorded_at
+			)
+			SELECT c.code, i.recorded_at FROM inserted i JOIN courses c ON c.id = i.course_id`,
+			studentID, courseID, grade,
+		).Scan(&rec.Code, &rec.RecordedAt)
+	})
+	return rec, classify(err)
+}
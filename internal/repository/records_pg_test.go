@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgRecordRepository_Eligibility(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	testdb.Exec(t, pool, `
+		INSERT INTO courses (code, title, credits, department) VALUES
+			('CS201', 'Data Structures', 3, 'CS'),
+			('MA101', 'Calculus I', 3, 'MA')`)
+	terms := NewPgTermRepository(pool)
+	requisites := NewPgRequisiteRepository(pool)
+	records := NewPgRecordRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	ctx := context.Background()
+
+	// CS201 needs CS101 with at least a C, and MA101 alongside it.
+	reqs, err := requisites.SetRequisites(ctx, courseIDByCode(t, pool, "CS201"), []RequisiteGroup{
+		{Kind: Prerequisite, Options: []Requirement{{Code: "CS101", MinGrade: "C"}}},
+		{Kind: Corequisite, Options: []Requirement{{Code: "MA101"}}},
+	})
+	if err != nil {
+		t.Fatalf("SetRequisites: %v", err)
+	}
+	missing := func() []RequisiteGroup {
+		t.Helper()
+		record, err := records.StudentRecord(ctx, "amani@student.school.edu")
+		if err != nil {
+			t.Fatalf("StudentRecord: %v", err)
+		}
+		return reqs.Missing(record)
+	}
+
+	if got := missing(); len(got) != 2 {
+		t.Errorf("Expected both groups missing with an empty record, got %+v", got)
+	}
+
+	cs101 := courseIDByCode(t, pool, "CS101")
+	if _, err := records.RecordGrade(ctx, "amani@student.school.edu", cs101, "D"); err != nil {
+		t.Fatalf("RecordGrade D: %v", err)
+	}
+	rec, err := records.RecordGrade(ctx, "amani@student.school.edu", cs101, "B")
+	if err != nil {
+		t.Fatalf("RecordGrade B: %v", err)
+	}
+	if rec.Code != "CS101" || rec.Grade == nil || *rec.Grade != "B" || rec.RecordedAt.IsZero() {
+		t.Errorf("Expected the recorded B in CS101, got %+v", rec)
+	}
+	if got := missing(); len(got) != 1 || got[0].Kind != Corequisite {
+		t.Errorf("Expected only the co-requisite missing after a B, got %+v", got)
+	}
+
+	// Taking MA101 this term meets the co-requisite.
+	offering := createOffering(t, terms, pool, "MA101", 10)
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false); err != nil {
+		t.Fatalf("Enroll: %v", err)
+	}
+	if got := missing(); len(got) != 0 {
+		t.Errorf("Expected a current MA101 enrollment to meet the co-requisite, got %+v", got)
+	}
+
+	if _, err := records.RecordGrade(ctx, "nobody@student.school.edu", cs101, "A"); !errors.Is(err, ErrStudentNotFound) {
+		t.Errorf("Expected ErrStudentNotFound, got %v", err)
+	}
+	if _, err := records.RecordGrade(ctx, "amani@student.school.edu", 0, "A"); !errors.Is(err, ErrCourseNotFound) {
+		t.Errorf("Expected ErrCourseNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,31 @@
+// Package repository hides storage behind interfaces so handlers can be
+// exercised with in-memory fakes instead of a live Postgres.
+package repository
+
+import (
+	"context"
+
+	"elimu-go/internal/tracing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Connect opens a pgx pool with query tracing and verifies the database is
+// reachable.
+func Connect(ctx context.Context, connString string) (*pgxpool.Pool, error) {
+	cfg, err := pgxpool.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ConnConfig.Tracer = tracing.NewQueryTracer()
+
+	pool, err := pgxpool.NewWithConfig(ctx, cfg)
+	if err != nil {
+		return nil, err
+	}
+	if err := pool.Ping(ctx); err != nil {
+		pool.Close()
+		return nil, err
+	}
+	return pool, nil
+}
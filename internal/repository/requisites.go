@@ -0,0 +1,110 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+)
+
+var ErrRequisiteCycle = fmt.Errorf("%w: prerequisites would form a cycle", ErrConflict)
+
+// Requisite kinds. A prerequisite must be passed before enrolling; a
+// co-requisite may also be taken in the same term.
+const (
+	Prerequisite = "prerequisite"
+	Corequisite  = "corequisite"
+)
+
+// Requirement is one way of meeting a requisite group.
+type Requirement struct {
+	CourseID int    `json:"course_id"`
+	Code     string `json:"code"`
+
+	// MinGrade is the lowest acceptable grade. Empty accepts any pass.
+	MinGrade string `json:"min_grade,omitempty"`
+}
+
+// RequisiteGroup is met when any one of its options is.
+type RequisiteGroup struct {
+	Kind    string        `json:"kind"`
+	Options []Requirement `json:"options"`
+}
+
+// Requisites are everything a course requires: all of its groups.
+type Requisites struct {
+	CourseID int              `json:"course_id"`
+	Groups   []RequisiteGroup `json:"groups"`
+}
+
+// Missing returns the groups that record does not meet.
+func (r Requisites) Missing(record []AcademicRecord) []RequisiteGroup {
+	missing := []RequisiteGroup{}
+	for _, g := range r.Groups {
+		if !g.metBy(record) {
+			missing = append(missing, g)
+		}
+	}
+	return missing
+}
+
+func (g RequisiteGroup) metBy(record []AcademicRecord) bool {
+	for _, opt := range g.Options {
+		for _, rec := range record {
+			if rec.CourseID != opt.CourseID {
+				continue
+			}
+			if rec.Grade == nil {
+				if g.Kind == Corequisite {
+					return true
+				}
+				continue
+			}
+			if GradeAtLeast(*rec.Grade, opt.MinGrade) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// prerequisiteCycle returns a path of course IDs from start back to start
+// along edges, or nil if there is none. edges maps a course to the courses
+// it requires.
+func prerequisiteCycle(edges map[int][]int, start int) []int {
+	visited := make(map[int]bool)
+	var path []int
+
+	var visit func(id int) bool
+	visit = func(id int) bool {
+		path = append(path, id)
+		for _, next := range edges[id] {
+			if next == start {
+				path = append(path, next)
+				return true
+			}
+			if !visited[next] {
+				visited[next] = true
+				if visit(next) {
+					return true
+				}
+			}
+		}
+		path = path[:len(path)-1]
+		return false
+	}
+
+	if visit(start) {
+		return path
+	}
+	return nil
+}
+
+// RequisiteRepository stores the requirements between courses.
+type RequisiteRepository interface {
+	// GetRequisites returns the course's requisites, or ErrCourseNotFound.
+	GetRequisites(ctx context.Context, courseID int) (Requisites, error)
+
+	// SetRequisites replaces the course's requisites. Options name courses
+	// by Code. It returns ErrCourseNotFound for unknown courses and
+	// ErrRequisiteCycle if a course would end up requiring itself.
+	SetRequisites(ctx context.Context, courseID int, groups []RequisiteGroup) (Requisites, error)
+}
@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// MemoryRequisiteRepository is an in-memory RequisiteRepository for tests
+// over the courses of a MemoryCourseRepository.
+type MemoryRequisiteRepository struct {
+	mu      sync.RWMutex
+	courses *MemoryCourseRepository
+	groups  map[int][]RequisiteGroup
+
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func NewMemoryRequisiteRepository(courses *MemoryCourseRepository) *MemoryRequisiteRepository {
+	return &MemoryRequisiteRepository{courses: courses, groups: make(map[int][]RequisiteGroup)}
+}
+
+func (r *MemoryRequisiteRepository) requisites(courseID int) Requisites {
+	return Requisites{CourseID: courseID, Groups: append([]RequisiteGroup{}, r.groups[courseID]...)}
+}
+
+func (r *MemoryRequisiteRepository) GetRequisites(_ context.Context, courseID int) (Requisites, error) {
+	if r.Err != nil {
+		return Requisites{}, r.Err
+	}
+	if _, ok := r.courses.course(courseID); !ok {
+		return Requisites{}, ErrCourseNotFound
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.requisites(courseID), nil
+}
+
+func (r *MemoryRequisiteRepository) SetRequisites(_ context.Context, courseID int, groups []RequisiteGroup) (Requisites, error) {
+	if r.Err != nil {
+		return Requisites{}, r.Err
+	}
+	if _, ok := r.courses.course(courseID); !ok {
+		return Requisites{}, ErrCourseNotFound
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	edges := make(map[int][]int)
+	for id, gs := range r.groups {
+		for _, g := range gs {
+			for _, opt := range g.Options {
+				if id != courseID && g.Kind == Prerequisite {
+					edges[id] = append(edges[id], opt.CourseID)
+				}
+			}
+		}
+	}
+
+	resolved := make([]RequisiteGroup, len(groups))
+	for i, g := range groups {
+		resolved[i] = RequisiteGroup{Kind: g.Kind}
+		for _, opt := range g.Options {
+			c, ok := r.courses.courseByCode(opt.Code)
+			if !ok {
+				return Requisites{}, fmt.Errorf("%w: %s", ErrCourseNotFound, opt.Code)
+			}
+			if c.ID == courseID {
+				return Requisites{}, fmt.Errorf("%w: %s requires itself", ErrRequisiteCycle, opt.Code)
+			}
+			if g.Kind == Prerequisite {
+				edges[courseID] = append(edges[courseID], c.ID)
+			}
+			resolved[i].Options = append(resolved[i].Options, Requirement{CourseID: c.ID, Code: c.Code, MinGrade: opt.MinGrade})
+		}
+		slices.SortFunc(resolved[i].Options, func(a, b Requirement) int { return cmp.Compare(a.Code, b.Code) })
+	}
+	if cycle := prerequisiteCycle(edges, courseID); cycle != nil {
+		path := make([]string, len(cycle))
+		for i, id := range cycle {
+			c, _ := r.courses.course(id)
+			path[i] = c.Code
+		}
+		return Requisites{}, fmt.Errorf("%w: %s", ErrRequisiteCycle, strings.Join(path, " -> "))
+	}
+
+	r.groups[courseID] = resolved
+	return r.requisites(courseID), nil
+}
@@ -0,0 +1,240 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgRequisiteRepository stores course requisites in Postgres.
+type PgRequisiteRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRequisiteRepository(db *pgxpool.Pool) *PgRequisiteRepository {
+	return &PgRequisiteRepository{db: db}
+}
+
+func (r *PgRequisiteRepository) GetRequisites(ctx context.Context, courseID int) (Requisites, error) {
+	if err := courseExists(ctx, r.db, courseID); err != nil {
+		return Requisites{}, classify(err)
+	}
+	reqs, err := loadRequisites(ctx, r.db, courseID)
+	return reqs, classify(err)
+}
+
+func (r *PgRequisiteRepository) SetRequisites(ctx context.Context, courseID int, groups []RequisiteGroup) (Requisites, error) {
+	var reqs Requisites
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// Serialize edits so two concurrent changes cannot each close half
+		// of a cycle.
+		if _, err := tx.Exec(ctx, `LOCK TABLE course_requisite_groups IN SHARE ROW EXCLUSIVE MODE`); err != nil {
+			return err
+		}
+		if err := courseExists(ctx, tx, courseID); err != nil {
+			return err
+		}
+
+		codes := make(map[string]int)
+		for _, g := range groups {
+			for _, opt := range g.Options {
+				codes[opt.Code] = 0
+			}
+		}
+		if err := resolveCourseCodes(ctx, tx, codes); err != nil {
+			return err
+		}
+
+		edges, err := prerequisiteEdges(ctx, tx, courseID)
+		if err != nil {
+			return err
+		}
+		for _, g := range groups {
+			for _, opt := range g.Options {
+				id := codes[opt.Code]
+				if id == courseID {
+					return fmt.Errorf("%w: %s requires itself", ErrRequisiteCycle, opt.Code)
+				}
+				if g.Kind == Prerequisite {
+					edges[courseID] = append(edges[courseID], id)
+				}
+			}
+		}
+		if cycle := prerequisiteCycle(edges, courseID); cycle != nil {
+			return cycleError(ctx, tx, cycle)
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM course_requisite_groups WHERE course_id=$1`, courseID); err != nil {
+			return err
+		}
+		for _, g := range groups {
+			var groupID int
+			err := tx.QueryRow(ctx, `
+				INSERT INTO course_requisite_groups (course_id, kind)
+				VALUES ($1, $2)
+				RETURNING id`,
+				courseID, g.Kind,
+			).Scan(&groupID)
+			if err != nil {
+				return err
+			}
+
+			ids := make([]int, len(g.Options))
+			grades := make([]string, len(g.Options))
+			for i, opt := range g.Options {
+				ids[i] = codes[opt.Code]
+				grades[i] = opt.MinGrade
+			}
+			_, err = tx.Exec(ctx, `
+				INSERT INTO course_requisites (group_id, required_course_id, min_grade)
+				SELECT $1, unnest($2::int[]), NULLIF(unnest($3::text[]), '')`,
+				groupID, ids, grades)
+			if err != nil {
+				return err
+			}
+		}
+
+		reqs, err = loadRequisites(ctx, tx, courseID)
+		return err
+	})
+	return reqs, classify(err)
+}
+
+func courseExists(ctx context.Context, db dbtx, courseID int) error {
+	var exists bool
+	err := db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM courses WHERE id=$1)`, courseID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrCourseNotFound
+	}
+	return nil
+}
+
+// resolveCourseCodes fills in the ID of every code in codes.
+func resolveCourseCodes(ctx context.Context, db dbtx, codes map[string]int) error {
+	if len(codes) == 0 {
+		return nil
+	}
+	list := make([]string, 0, len(codes))
+	for code := range codes {
+		list = append(list, code)
+	}
+
+	rows, err := db.Query(ctx, `SELECT id, code FROM courses WHERE code = ANY($1)`, list)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id   int
+			code string
+		)
+		if err := rows.Scan(&id, &code); err != nil {
+			return err
+		}
+		codes[code] = id
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, code := range list {
+		if codes[code] == 0 {
+			return fmt.Errorf("%w: %s", ErrCourseNotFound, code)
+		}
+	}
+	return nil
+}
+
+// prerequisiteEdges returns the prerequisite graph of every course but
+// courseID, whose requisites are about to be replaced.
+func prerequisiteEdges(ctx context.Context, db dbtx, courseID int) (map[int][]int, error) {
+	rows, err := db.Query(ctx, `
+		SELECT g.course_id, r.required_course_id
+		FROM course_requisite_groups g JOIN course_requisites r ON r.group_id = g.id
+		WHERE g.kind = 'prerequisite' AND g.course_id <> $1`, courseID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	edges := make(map[int][]int)
+	for rows.Next() {
+		var from, to int
+		if err := rows.Scan(&from, &to); err != nil {
+			return nil, err
+		}
+		edges[from] = append(edges[from], to)
+	}
+	return edges, rows.Err()
+}
+
+// cycleError describes a cycle of course IDs by course code.
+func cycleError(ctx context.Context, db dbtx, cycle []int) error {
+	codes := make(map[int]string, len(cycle))
+	rows, err := db.Query(ctx, `SELECT id, code FROM courses WHERE id = ANY($1)`, cycle)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			id   int
+			code string
+		)
+		if err := rows.Scan(&id, &code); err != nil {
+			return err
+		}
+		codes[id] = code
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	path := make([]string, len(cycle))
+	for i, id := range cycle {
+		path[i] = codes[id]
+	}
+	return fmt.Errorf("%w: %s", ErrRequisiteCycle, strings.Join(path, " -> "))
+}
+
+func loadRequisites(ctx context.Context, db dbtx, courseID int) (Requisites, error) {
+	reqs := Requisites{CourseID: courseID, Groups: []RequisiteGroup{}}
+
+	rows, err := db.Query(ctx, `
+		SELECT g.id, g.kind, r.required_course_id, c.code, COALESCE(r.min_grade, '')
+		FROM course_requisite_groups g
+		JOIN course_requisites r ON r.group_id = g.id
+		JOIN courses c ON c.id = r.required_course_id
+		WHERE g.course_id = $1
+		ORDER BY g.id, c.code`, courseID)
+	if err != nil {
+		return reqs, err
+	}
+	defer rows.Close()
+
+	lastGroup := 0
+	for rows.Next() {
+		var (
+			groupID int
+			kind    string
+			opt     Requirement
+		)
+		if err := rows.Scan(&groupID, &kind, &opt.CourseID, &opt.Code, &opt.MinGrade); err != nil {
+			return reqs, err
+		}
+		if groupID != lastGroup {
+			reqs.Groups = append(reqs.Groups, RequisiteGroup{Kind: kind})
+			lastGroup = groupID
+		}
+		g := &reqs.Groups[len(reqs.Groups)-1]
+		g.Options = append(g.Options, opt)
+	}
+	return reqs, rows.Err()
+}
@@ -0,0 +1,56 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgRequisiteRepository_RejectsCycles(t *testing.T) {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO courses (code, title, credits, department) VALUES
+			('CS101', 'Intro to Programming', 3, 'CS'),
+			('CS201', 'Data Structures', 3, 'CS'),
+			('CS301', 'Algorithms', 3, 'CS')`)
+	requisites := NewPgRequisiteRepository(pool)
+	ctx := context.Background()
+
+	ids := make(map[string]int)
+	for _, code := range []string{"CS101", "CS201", "CS301"} {
+		ids[code] = courseIDByCode(t, pool, code)
+	}
+
+	requires := func(code string) []RequisiteGroup {
+		return []RequisiteGroup{{Kind: Prerequisite, Options: []Requirement{{Code: code, MinGrade: "C"}}}}
+	}
+
+	if _, err := requisites.SetRequisites(ctx, ids["CS201"], requires("CS101")); err != nil {
+		t.Fatalf("SetRequisites CS201: %v", err)
+	}
+	reqs, err := requisites.SetRequisites(ctx, ids["CS301"], requires("CS201"))
+	if err != nil {
+		t.Fatalf("SetRequisites CS301: %v", err)
+	}
+	if len(reqs.Groups) != 1 || reqs.Groups[0].Options[0].Code != "CS201" || reqs.Groups[0].Options[0].MinGrade != "C" {
+		t.Errorf("Expected CS301 to require CS201 with a C, got %+v", reqs)
+	}
+
+	_, err = requisites.SetRequisites(ctx, ids["CS101"], requires("CS301"))
+	if !errors.Is(err, ErrRequisiteCycle) || !strings.Contains(err.Error(), "CS101 -> CS301 -> CS201 -> CS101") {
+		t.Errorf("Expected the cycle to be reported, got %v", err)
+	}
+
+	// Co-requisites may point at each other.
+	coreq := []RequisiteGroup{{Kind: Corequisite, Options: []Requirement{{Code: "CS201"}}}}
+	if _, err := requisites.SetRequisites(ctx, ids["CS101"], coreq); err != nil {
+		t.Errorf("Expected a co-requisite back edge to be allowed, got %v", err)
+	}
+
+	if _, err := requisites.SetRequisites(ctx, ids["CS101"], requires("CS999")); !errors.Is(err, ErrCourseNotFound) {
+		t.Errorf("Expected ErrCourseNotFound for an unknown code, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package repository
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestGradeAtLeast(t *testing.T) {
+	cases := []struct {
+		grade, min string
+		want       bool
+	}{
+		{"A", "C", true},
+		{"C", "C", true},
+		{"C-", "C", false},
+		{"D-", "", true},
+		{"F", "", false},
+		{"Z", "", false},
+	}
+	for _, tc := range cases {
+		if got := GradeAtLeast(tc.grade, tc.min); got != tc.want {
+			t.Errorf("GradeAtLeast(%q, %q): expected %v, got %v", tc.grade, tc.min, tc.want, got)
+		}
+	}
+}
+
+func TestRequisites_Missing(t *testing.T) {
+	grade := func(g string) *string { return &g }
+
+	reqs := Requisites{Groups: []RequisiteGroup{
+		// CS101 with at least a C, or MATH101.
+		{Kind: Prerequisite, Options: []Requirement{{CourseID: 1, MinGrade: "C"}, {CourseID: 2}}},
+		{Kind: Corequisite, Options: []Requirement{{CourseID: 3}}},
+	}}
+
+	cases := map[string]struct {
+		record  []AcademicRecord
+		missing int
+	}{
+		"nothing taken":         {nil, 2},
+		"grade too low":         {[]AcademicRecord{{CourseID: 1, Grade: grade("D")}, {CourseID: 3, Grade: nil}}, 1},
+		"retake passed":         {[]AcademicRecord{{CourseID: 1, Grade: grade("D")}, {CourseID: 1, Grade: grade("B")}, {CourseID: 3, Grade: grade("A")}}, 0},
+		"alternative option":    {[]AcademicRecord{{CourseID: 2, Grade: grade("D-")}, {CourseID: 3, Grade: nil}}, 0},
+		"prerequisite underway": {[]AcademicRecord{{CourseID: 1, Grade: nil}, {CourseID: 3, Grade: nil}}, 1},
+	}
+	for name, tc := range cases {
+		if got := reqs.Missing(tc.record); len(got) != tc.missing {
+			t.Errorf("%s: expected %d missing groups, got %+v", name, tc.missing, got)
+		}
+	}
+}
+
+func TestPrerequisiteCycle(t *testing.T) {
+	edges := map[int][]int{
+		1: {2},
+		2: {3, 4},
+		4: {5},
+	}
+	if cycle := prerequisiteCycle(edges, 1); cycle != nil {
+		t.Errorf("Expected no cycle, got %v", cycle)
+	}
+
+	edges[5] = []int{1}
+	if cycle := prerequisiteCycle(edges, 1); !slices.Equal(cycle, []int{1, 2, 4, 5, 1}) {
+		t.Errorf("Expected 1 -> 2 -> 4 -> 5 -> 1, got %v", cycle)
+	}
+}
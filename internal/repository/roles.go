@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrStaffNotFound       = fmt.Errorf("staff member %w", ErrNotFound)
+	ErrRoleRequestNotFound = fmt.Errorf("role request %w", ErrNotFound)
+	ErrRoleGrantNotFound   = fmt.Errorf("active role grant %w", ErrNotFound)
+	ErrRequestNotPending   = fmt.Errorf("%w: role request is no longer pending", ErrConflict)
+	ErrRequestExpired      = fmt.Errorf("%w: role request has expired", ErrConflict)
+	ErrSelfApproval        = errors.New("role request cannot be decided by its requester or the staff member it elevates")
+)
+
+// RoleGrant records a role being given to a staff member. PreviousRole is
+// restored when the grant is revoked or expires.
+type RoleGrant struct {
+	ID           int        `json:"id"`
+	Email        string     `json:"email"`
+	Role         string     `json:"role"`
+	PreviousRole *string    `json:"previous_role"`
+	GrantedBy    string     `json:"granted_by"`
+	ApprovedBy   *string    `json:"approved_by"`
+	ExpiresAt    *time.Time `json:"expires_at"`
+	RevokedAt    *time.Time `json:"revoked_at"`
+	CreatedAt    time.Time  `json:"created_at"`
+}
+
+// RoleRequest is a pending elevation to a privileged role awaiting a second
+// admin's approval.
+type RoleRequest struct {
+	ID             int        `json:"id"`
+	Email          string     `json:"email"`
+	Role           string     `json:"role"`
+	GrantExpiresAt *time.Time `json:"grant_expires_at"`
+	Reason         string     `json:"reason"`
+	RequestedBy    string     `json:"requested_by"`
+	Status         string     `json:"status"`
+	DecidedBy      *string    `json:"decided_by"`
+	DecidedAt      *time.Time `json:"decided_at"`
+	ExpiresAt      time.Time  `json:"expires_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+// decidable reports why decidedBy may not decide the request at now, if
+// they may not. Neither the requester nor the staff member being elevated
+// may decide it. Emails are compared without regard to case, as Google
+// accounts are.
+func (r RoleRequest) decidable(decidedBy string, now time.Time) error {
+	switch {
+	case r.Status != "pending":
+		return ErrRequestNotPending
+	case !r.ExpiresAt.After(now):
+		return ErrRequestExpired
+	case strings.EqualFold(r.RequestedBy, decidedBy), strings.EqualFold(r.Email, decidedBy):
+		return ErrSelfApproval
+	}
+	return nil
+}
+
+// RoleChange is the effective role of a staff member after a grant changed.
+type RoleChange struct {
+	Email string
+	Role  string
+}
+
+// RoleRepository manages role grants and privileged role requests.
+type RoleRepository interface {
+	// Grant sets the staff member's role immediately.
+	Grant(ctx context.Context, g RoleGrant) (RoleGrant, error)
+	ListGrants(ctx context.Context, email string, activeOnly bool) ([]RoleGrant, error)
+
+	// RevokeGrant ends an active grant. The returned change is nil when a
+	// newer grant still determines the staff member's role.
+	RevokeGrant(ctx context.Context, id int) (*RoleChange, error)
+
+	CreateRequest(ctx context.Context, r RoleRequest) (RoleRequest, error)
+	ListRequests(ctx context.Context, status string) ([]RoleRequest, error)
+
+	// DecideRequest approves or rejects a pending request. Approval applies
+	// the grant atomically with the decision.
+	DecideRequest(ctx context.Context, id int, decidedBy string, approve bool) (RoleRequest, *RoleGrant, error)
+
+	// Expire marks stale requests as expired and reverts time-bound grants
+	// that have run out, returning the number of requests expired and the
+	// roles that changed.
+	Expire(ctx context.Context) (int, []RoleChange, error)
+}
@@ -0,0 +1,222 @@
+package repository
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryRoleRepository is an in-memory RoleRepository for tests. Grants
+// change staff roles in the MemoryUserRepository it was created with.
+type MemoryRoleRepository struct {
+	mu       sync.Mutex
+	users    *MemoryUserRepository
+	grants   []RoleGrant
+	requests []RoleRequest
+	nextID   int
+
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func NewMemoryRoleRepository(users *MemoryUserRepository) *MemoryRoleRepository {
+	return &MemoryRoleRepository{users: users}
+}
+
+func (r *MemoryRoleRepository) id() int {
+	r.nextID++
+	return r.nextID
+}
+
+// applyGrant mirrors applyRoleGrant.
+func (r *MemoryRoleRepository) applyGrant(g RoleGrant) (RoleGrant, error) {
+	role, ok := r.users.staffRole(g.Email)
+	if !ok {
+		return g, ErrStaffNotFound
+	}
+	g.PreviousRole = nil
+	if role != "" {
+		g.PreviousRole = &role
+	}
+	g.ID = r.id()
+	g.RevokedAt = nil
+	g.CreatedAt = time.Now()
+	r.grants = append(r.grants, g)
+	r.users.SetStaffRole(g.Email, g.Role)
+	return g, nil
+}
+
+// revertGrant mirrors revertRoleGrant.
+func (r *MemoryRoleRepository) revertGrant(i int) *RoleChange {
+	now := time.Now()
+	g := &r.grants[i]
+	g.RevokedAt = &now
+
+	for j := i + 1; j < len(r.grants); j++ {
+		if newer := &r.grants[j]; newer.Email == g.Email && newer.RevokedAt == nil {
+			newer.PreviousRole = g.PreviousRole
+			return nil
+		}
+	}
+
+	change := &RoleChange{Email: g.Email}
+	if g.PreviousRole != nil {
+		change.Role = *g.PreviousRole
+	}
+	r.users.SetStaffRole(g.Email, change.Role)
+	return change
+}
+
+func (r *MemoryRoleRepository) Grant(_ context.Context, g RoleGrant) (RoleGrant, error) {
+	if r.Err != nil {
+		return g, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.applyGrant(g)
+}
+
+func (r *MemoryRoleRepository) ListGrants(_ context.Context, email string, activeOnly bool) ([]RoleGrant, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	grants := []RoleGrant{}
+	for i := len(r.grants) - 1; i >= 0; i-- {
+		g := r.grants[i]
+		if (email == "" || g.Email == email) && (!activeOnly || g.RevokedAt == nil) {
+			grants = append(grants, g)
+		}
+	}
+	return grants, nil
+}
+
+func (r *MemoryRoleRepository) RevokeGrant(_ context.Context, id int) (*RoleChange, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i, g := range r.grants {
+		if g.ID == id && g.RevokedAt == nil {
+			return r.revertGrant(i), nil
+		}
+	}
+	return nil, ErrRoleGrantNotFound
+}
+
+func (r *MemoryRoleRepository) CreateRequest(_ context.Context, req RoleRequest) (RoleRequest, error) {
+	if r.Err != nil {
+		return req, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.users.staffRole(req.Email); !ok {
+		return req, ErrStaffNotFound
+	}
+	req.ID = r.id()
+	req.Status = "pending"
+	req.DecidedBy = nil
+	req.DecidedAt = nil
+	req.CreatedAt = time.Now()
+	r.requests = append(r.requests, req)
+	return req, nil
+}
+
+func (r *MemoryRoleRepository) ListRequests(_ context.Context, status string) ([]RoleRequest, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requests := []RoleRequest{}
+	for i := len(r.requests) - 1; i >= 0; i-- {
+		if req := r.requests[i]; status == "" || req.Status == status {
+			requests = append(requests, req)
+		}
+	}
+	return requests, nil
+}
+
+func (r *MemoryRoleRepository) DecideRequest(_ context.Context, id int, decidedBy string, approve bool) (RoleRequest, *RoleGrant, error) {
+	if r.Err != nil {
+		return RoleRequest{}, nil, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range r.requests {
+		req := &r.requests[i]
+		if req.ID != id {
+			continue
+		}
+		now := time.Now()
+		if err := req.decidable(decidedBy, now); err != nil {
+			return *req, nil, err
+		}
+
+		var grant *RoleGrant
+		status := "rejected"
+		if approve {
+			status = "approved"
+			g, err := r.applyGrant(RoleGrant{
+				Email:      req.Email,
+				Role:       req.Role,
+				GrantedBy:  req.RequestedBy,
+				ApprovedBy: &decidedBy,
+				ExpiresAt:  req.GrantExpiresAt,
+			})
+			if err != nil {
+				return *req, nil, err
+			}
+			grant = &g
+		}
+		req.Status = status
+		req.DecidedBy = &decidedBy
+		req.DecidedAt = &now
+		return *req, grant, nil
+	}
+	return RoleRequest{}, nil, ErrRoleRequestNotFound
+}
+
+func (r *MemoryRoleRepository) Expire(context.Context) (int, []RoleChange, error) {
+	if r.Err != nil {
+		return 0, nil, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	expired := 0
+	for i := range r.requests {
+		if req := &r.requests[i]; req.Status == "pending" && !req.ExpiresAt.After(now) {
+			req.Status = "expired"
+			expired++
+		}
+	}
+
+	// Newest first, as the Postgres sweep reverts them.
+	var changes []RoleChange
+	for i := len(r.grants) - 1; i >= 0; i-- {
+		g := r.grants[i]
+		if g.RevokedAt != nil || g.ExpiresAt == nil || g.ExpiresAt.After(now) {
+			continue
+		}
+		if change := r.revertGrant(i); change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	return expired, changes, nil
+}
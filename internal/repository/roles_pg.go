@@ -0,0 +1,279 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const roleGrantColumns = `id, email, role, previous_role, granted_by, approved_by,
+	expires_at, revoked_at, created_at`
+
+const roleRequestColumns = `id, email, role, grant_expires_at, reason, requested_by,
+	status, decided_by, decided_at, expires_at, created_at`
+
+func scanRoleGrant(row pgx.Row) (RoleGrant, error) {
+	var g RoleGrant
+	err := row.Scan(&g.ID, &g.Email, &g.Role, &g.PreviousRole, &g.GrantedBy,
+		&g.ApprovedBy, &g.ExpiresAt, &g.RevokedAt, &g.CreatedAt)
+	return g, err
+}
+
+func scanRoleRequest(row pgx.Row) (RoleRequest, error) {
+	var r RoleRequest
+	err := row.Scan(&r.ID, &r.Email, &r.Role, &r.GrantExpiresAt, &r.Reason,
+		&r.RequestedBy, &r.Status, &r.DecidedBy, &r.DecidedAt, &r.ExpiresAt, &r.CreatedAt)
+	return r, err
+}
+
+// applyRoleGrant sets the staff member's role and records the grant.
+func applyRoleGrant(ctx context.Context, tx pgx.Tx, g RoleGrant) (RoleGrant, error) {
+	var previous *string
+	err := tx.QueryRow(ctx,
+		`SELECT role FROM staff WHERE email=$1 FOR UPDATE`, g.Email,
+	).Scan(&previous)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return g, ErrStaffNotFound
+	}
+	if err != nil {
+		return g, err
+	}
+
+	g, err = scanRoleGrant(tx.QueryRow(ctx, `
+		INSERT INTO role_grants (email, role, previous_role, granted_by, approved_by, expires_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING `+roleGrantColumns,
+		g.Email, g.Role, previous, g.GrantedBy, g.ApprovedBy, g.ExpiresAt))
+	if err != nil {
+		return g, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE staff SET role=$2 WHERE email=$1`, g.Email, g.Role)
+	return g, err
+}
+
+// revertRoleGrant ends a grant. Grants stack, so if a newer grant is still
+// active it inherits this grant's previous role instead of staff.role being
+// changed underneath it.
+func revertRoleGrant(ctx context.Context, tx pgx.Tx, g RoleGrant) (*RoleChange, error) {
+	_, err := tx.Exec(ctx,
+		`UPDATE role_grants SET revoked_at=NOW() WHERE id=$1`, g.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var newer int
+	err = tx.QueryRow(ctx, `
+		SELECT id FROM role_grants
+		WHERE email=$1 AND revoked_at IS NULL AND id > $2
+		ORDER BY id LIMIT 1`,
+		g.Email, g.ID,
+	).Scan(&newer)
+	if err == nil {
+		_, err = tx.Exec(ctx,
+			`UPDATE role_grants SET previous_role=$2 WHERE id=$1`, newer, g.PreviousRole)
+		return nil, err
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE staff SET role=$2 WHERE email=$1`, g.Email, g.PreviousRole)
+	if err != nil {
+		return nil, err
+	}
+
+	change := &RoleChange{Email: g.Email}
+	if g.PreviousRole != nil {
+		change.Role = *g.PreviousRole
+	}
+	return change, nil
+}
+
+// PgRoleRepository stores role grants and requests in Postgres.
+type PgRoleRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRoleRepository(db *pgxpool.Pool) *PgRoleRepository {
+	return &PgRoleRepository{db: db}
+}
+
+func (r *PgRoleRepository) Grant(ctx context.Context, g RoleGrant) (RoleGrant, error) {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		g, err = applyRoleGrant(ctx, tx, g)
+		return err
+	})
+	return g, classify(err)
+}
+
+func (r *PgRoleRepository) ListGrants(ctx context.Context, email string, activeOnly bool) ([]RoleGrant, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+roleGrantColumns+` FROM role_grants
+		WHERE ($1 = '' OR email = $1)
+		  AND (NOT $2 OR revoked_at IS NULL)
+		ORDER BY id DESC`,
+		email, activeOnly)
+	if err != nil {
+		return nil, classify(err)
+	}
+	defer rows.Close()
+
+	grants := []RoleGrant{}
+	for rows.Next() {
+		g, err := scanRoleGrant(rows)
+		if err != nil {
+			return nil, classify(err)
+		}
+		grants = append(grants, g)
+	}
+	return grants, classify(rows.Err())
+}
+
+func (r *PgRoleRepository) RevokeGrant(ctx context.Context, id int) (*RoleChange, error) {
+	var change *RoleChange
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		g, err := scanRoleGrant(tx.QueryRow(ctx, `
+			SELECT `+roleGrantColumns+` FROM role_grants
+			WHERE id=$1 AND revoked_at IS NULL
+			FOR UPDATE`, id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleGrantNotFound
+		}
+		if err != nil {
+			return err
+		}
+		change, err = revertRoleGrant(ctx, tx, g)
+		return err
+	})
+	return change, classify(err)
+}
+
+func (r *PgRoleRepository) CreateRequest(ctx context.Context, req RoleRequest) (RoleRequest, error) {
+	req, err := scanRoleRequest(r.db.QueryRow(ctx, `
+		INSERT INTO role_requests (email, role, grant_expires_at, reason, requested_by, expires_at)
+		SELECT email, $2::varchar, $3::timestamptz, $4::text, $5::varchar, $6::timestamptz
+		FROM staff WHERE email=$1
+		RETURNING `+roleRequestColumns,
+		req.Email, req.Role, req.GrantExpiresAt, req.Reason, req.RequestedBy, req.ExpiresAt))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return req, ErrStaffNotFound
+	}
+	return req, classify(err)
+}
+
+func (r *PgRoleRepository) ListRequests(ctx context.Context, status string) ([]RoleRequest, error) {
+	rows, err := r.db.Query(ctx, `
+		SELECT `+roleRequestColumns+` FROM role_requests
+		WHERE ($1 = '' OR status = $1)
+		ORDER BY id DESC`,
+		status)
+	if err != nil {
+		return nil, classify(err)
+	}
+	defer rows.Close()
+
+	requests := []RoleRequest{}
+	for rows.Next() {
+		req, err := scanRoleRequest(rows)
+		if err != nil {
+			return nil, classify(err)
+		}
+		requests = append(requests, req)
+	}
+	return requests, classify(rows.Err())
+}
+
+func (r *PgRoleRepository) DecideRequest(ctx context.Context, id int, decidedBy string, approve bool) (RoleRequest, *RoleGrant, error) {
+	var (
+		req   RoleRequest
+		grant *RoleGrant
+	)
+
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var err error
+		req, err = scanRoleRequest(tx.QueryRow(ctx, `
+			SELECT `+roleRequestColumns+` FROM role_requests
+			WHERE id=$1 FOR UPDATE`, id))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrRoleRequestNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := req.decidable(decidedBy, time.Now()); err != nil {
+			return err
+		}
+
+		status := "rejected"
+		if approve {
+			status = "approved"
+			g, err := applyRoleGrant(ctx, tx, RoleGrant{
+				Email:      req.Email,
+				Role:       req.Role,
+				GrantedBy:  req.RequestedBy,
+				ApprovedBy: &decidedBy,
+				ExpiresAt:  req.GrantExpiresAt,
+			})
+			if err != nil {
+				return err
+			}
+			grant = &g
+		}
+
+		req, err = scanRoleRequest(tx.QueryRow(ctx, `
+			UPDATE role_requests
+			SET status=$2, decided_by=$3, decided_at=NOW()
+			WHERE id=$1
+			RETURNING `+roleRequestColumns,
+			id, status, decidedBy))
+		return err
+	})
+
+	return req, grant, classify(err)
+}
+
+func (r *PgRoleRepository) Expire(ctx context.Context) (int, []RoleChange, error) {
+	tag, err := r.db.Exec(ctx, `
+		UPDATE role_requests SET status='expired'
+		WHERE status='pending' AND expires_at <= NOW()`)
+	if err != nil {
+		return 0, nil, classify(err)
+	}
+
+	var changes []RoleChange
+	err = pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		rows, err := tx.Query(ctx, `
+			SELECT `+roleGrantColumns+` FROM role_grants
+			WHERE revoked_at IS NULL AND expires_at <= NOW()
+			ORDER BY id DESC
+			FOR UPDATE SKIP LOCKED`)
+		if err != nil {
+			return err
+		}
+		grants, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (RoleGrant, error) {
+			return scanRoleGrant(row)
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, g := range grants {
+			change, err := revertRoleGrant(ctx, tx, g)
+			if err != nil {
+				return err
+			}
+			if change != nil {
+				changes = append(changes, *change)
+			}
+		}
+		return nil
+	})
+
+	return int(tag.RowsAffected()), changes, classify(err)
+}
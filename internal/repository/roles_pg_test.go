@@ -0,0 +1,132 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"elimu-go/internal/testdb"
+)
+
+func newRoleRepos(t *testing.T) (*PgRoleRepository, *PgUserRepository) {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO staff (first_name, last_name, email, role) VALUES
+			('Grace', 'Mwangi', 'grace@school.edu', 'admin'),
+			('Peter', 'Kamau', 'peter@school.edu', 'cto'),
+			('Ruth', 'Njeri', 'ruth@school.edu', 'teacher')`)
+	return NewPgRoleRepository(pool), NewPgUserRepository(pool)
+}
+
+func TestPgRoleRepository_FourEyes(t *testing.T) {
+	roles, users := newRoleRepos(t)
+	ctx := context.Background()
+
+	req, err := roles.CreateRequest(ctx, RoleRequest{
+		Email:       "ruth@school.edu",
+		Role:        "admin",
+		RequestedBy: "grace@school.edu",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	})
+	if err != nil {
+		t.Fatalf("CreateRequest: %v", err)
+	}
+
+	for _, email := range []string{"grace@school.edu", "Grace@School.edu"} {
+		if _, _, err := roles.DecideRequest(ctx, req.ID, email, true); !errors.Is(err, ErrSelfApproval) {
+			t.Fatalf("Requester approving their own request as %s should fail, got %v", email, err)
+		}
+	}
+	if _, _, err := roles.DecideRequest(ctx, req.ID, "Ruth@school.edu", true); !errors.Is(err, ErrSelfApproval) {
+		t.Fatalf("Staff member approving their own elevation should fail, got %v", err)
+	}
+
+	_, grant, err := roles.DecideRequest(ctx, req.ID, "peter@school.edu", true)
+	if err != nil {
+		t.Fatalf("DecideRequest: %v", err)
+	}
+	if grant == nil || grant.PreviousRole == nil || *grant.PreviousRole != "teacher" {
+		t.Errorf("Expected grant recording previous role teacher, got %+v", grant)
+	}
+
+	if role, _ := users.FindRole(ctx, "ruth@school.edu"); role != "admin" {
+		t.Errorf("Expected ruth to be admin after approval, got %q", role)
+	}
+
+	if _, _, err := roles.DecideRequest(ctx, req.ID, "peter@school.edu", true); !errors.Is(err, ErrConflict) {
+		t.Errorf("Deciding twice should conflict, got %v", err)
+	}
+}
+
+func TestPgRoleRepository_RevokeStackedGrants(t *testing.T) {
+	roles, users := newRoleRepos(t)
+	ctx := context.Background()
+
+	first, err := roles.Grant(ctx, RoleGrant{Email: "ruth@school.edu", Role: "registrar", GrantedBy: "grace@school.edu"})
+	if err != nil {
+		t.Fatalf("Grant: %v", err)
+	}
+	if _, err := roles.Grant(ctx, RoleGrant{Email: "ruth@school.edu", Role: "invigilator", GrantedBy: "grace@school.edu"}); err != nil {
+		t.Fatalf("Grant: %v", err)
+	}
+
+	// Revoking the older grant must not disturb the role set by the newer one.
+	change, err := roles.RevokeGrant(ctx, first.ID)
+	if err != nil {
+		t.Fatalf("RevokeGrant: %v", err)
+	}
+	if change != nil {
+		t.Errorf("Revoking a superseded grant should not change the role, got %+v", change)
+	}
+	if role, _ := users.FindRole(ctx, "ruth@school.edu"); role != "invigilator" {
+		t.Errorf("Expected invigilator, got %q", role)
+	}
+
+	active, err := roles.ListGrants(ctx, "ruth@school.edu", true)
+	if err != nil || len(active) != 1 {
+		t.Fatalf("Expected one active grant, got %d (%v)", len(active), err)
+	}
+
+	change, err = roles.RevokeGrant(ctx, active[0].ID)
+	if err != nil {
+		t.Fatalf("RevokeGrant: %v", err)
+	}
+	if change == nil || change.Role != "teacher" {
+		t.Errorf("Expected ruth to revert to teacher, got %+v", change)
+	}
+}
+
+func TestPgRoleRepository_Expire(t *testing.T) {
+	roles, users := newRoleRepos(t)
+	ctx := context.Background()
+
+	soon := time.Now().Add(50 * time.Millisecond)
+	if _, err := roles.Grant(ctx, RoleGrant{Email: "ruth@school.edu", Role: "registrar", GrantedBy: "grace@school.edu", ExpiresAt: &soon}); err != nil {
+		t.Fatalf("Grant: %v", err)
+	}
+	if _, err := roles.CreateRequest(ctx, RoleRequest{
+		Email:       "ruth@school.edu",
+		Role:        "cto",
+		RequestedBy: "grace@school.edu",
+		ExpiresAt:   soon,
+	}); err != nil {
+		t.Fatalf("CreateRequest: %v", err)
+	}
+
+	time.Sleep(100 * time.Millisecond)
+
+	expired, changes, err := roles.Expire(ctx)
+	if err != nil {
+		t.Fatalf("Expire: %v", err)
+	}
+	if expired != 1 {
+		t.Errorf("Expected 1 expired request, got %d", expired)
+	}
+	if len(changes) != 1 || changes[0].Role != "teacher" {
+		t.Errorf("Expected ruth to revert to teacher, got %+v", changes)
+	}
+	if role, _ := users.FindRole(ctx, "ruth@school.edu"); role != "teacher" {
+		t.Errorf("Expected teacher after expiry, got %q", role)
+	}
+}
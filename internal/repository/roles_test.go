@@ -0,0 +1,35 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestRoleRequest_Decidable(t *testing.T) {
+	now := time.Now()
+	pending := RoleRequest{Status: "pending", Email: "ruth@school.edu", RequestedBy: "grace@school.edu", ExpiresAt: now.Add(time.Hour)}
+	expired := pending
+	expired.ExpiresAt = now.Add(-time.Minute)
+	approved := pending
+	approved.Status = "approved"
+
+	cases := []struct {
+		name      string
+		req       RoleRequest
+		decidedBy string
+		want      error
+	}{
+		{"second admin", pending, "peter@school.edu", nil},
+		{"requester", pending, "grace@school.edu", ErrSelfApproval},
+		{"requester in other case", pending, "Grace@SCHOOL.edu", ErrSelfApproval},
+		{"subject", pending, "Ruth@school.edu", ErrSelfApproval},
+		{"expired", expired, "peter@school.edu", ErrRequestExpired},
+		{"decided", approved, "peter@school.edu", ErrRequestNotPending},
+	}
+	for _, tc := range cases {
+		if err := tc.req.decidable(tc.decidedBy, now); !errors.Is(err, tc.want) {
+			t.Errorf("%s: expected %v, got %v", tc.name, tc.want, err)
+		}
+	}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// Roster change actions.
+const (
+	RosterAdded   = "added"
+	RosterRemoved = "removed"
+)
+
+// RosterEntry is a student enrolled in, or waiting for, an offering.
+type RosterEntry struct {
+	EnrollmentID int     `json:"enrollment_id"`
+	FirstName    string  `json:"first_name"`
+	LastName     string  `json:"last_name"`
+	Email        string  `json:"email"`
+	Cohort       *string `json:"cohort"`
+	Status       string  `json:"status"`
+
+	// WaitlistPosition counts from 1 and is only set while waitlisted.
+	WaitlistPosition int `json:"waitlist_position,omitempty"`
+
+	RequestedAt time.Time  `json:"requested_at"`
+	EnrolledAt  *time.Time `json:"enrolled_at"`
+}
+
+// RosterChange records staff adding or removing a student by hand. It
+// keeps the student and offering it describes, so the record survives
+// them; OfferingID and EnrollmentID are nil once those are deleted.
+type RosterChange struct {
+	ID           int       `json:"id"`
+	OfferingID   *int      `json:"offering_id"`
+	EnrollmentID *int      `json:"enrollment_id"`
+	StudentEmail string    `json:"student_email"`
+	TermCode     string    `json:"term_code"`
+	CourseCode   string    `json:"course_code"`
+	Section      string    `json:"section"`
+	Action       string    `json:"action"`
+	Actor        string    `json:"actor"`
+	Reason       string    `json:"reason"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+// RosterRepository lets staff see and manage who is in an offering.
+type RosterRepository interface {
+	// Roster lists the offering's enrolled students by name, then its
+	// waitlist in order.
+	Roster(ctx context.Context, offeringID int) ([]RosterEntry, error)
+
+	// AddStudent enrolls the student by hand, bypassing enrollment windows,
+	// capacity and timetable clashes. A waitlisted student is moved into
+	// the offering.
+	AddStudent(ctx context.Context, offeringID int, studentEmail, actor, reason string) (Enrollment, error)
+
+	// RemoveStudent drops an enrollment or waitlist place by hand and
+	// promotes waitlisted students into any seat that frees up.
+	RemoveStudent(ctx context.Context, offeringID, enrollmentID int, actor, reason string) (DropResult, error)
+
+	// RosterChanges lists the offering's manual roster changes, newest
+	// first.
+	RosterChanges(ctx context.Context, offeringID int) ([]RosterChange, error)
+
+	// TermRosterChanges lists the manual roster changes of every offering
+	// in the term, deleted ones included, newest first. A non-empty
+	// courseCode keeps only that course's changes.
+	TermRosterChanges(ctx context.Context, termID int, courseCode string) ([]RosterChange, error)
+}
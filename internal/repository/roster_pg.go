@@ -0,0 +1,188 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+func offeringExists(ctx context.Context, db dbtx, offeringID int) error {
+	var exists bool
+	err := db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM course_offerings WHERE id=$1)`, offeringID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrOfferingNotFound
+	}
+	return nil
+}
+
+// recordRosterChange audits a change to the enrollment, copying the
+// student and offering details onto the record.
+func recordRosterChange(ctx context.Context, tx pgx.Tx, enrollmentID int, action, actor, reason string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO roster_changes (term_id, offering_id, enrollment_id, action, actor, reason,
+			student_email, term_code, course_code, section)
+		SELECT t.id, o.id, e.id, $2, $3, $4, s.email, t.code, c.code, o.section
+		FROM enrollments e
+		JOIN students s ON s.id = e.student_id
+		JOIN course_offerings o ON o.id = e.offering_id
+		JOIN courses c ON c.id = o.course_id
+		JOIN terms t ON t.id = o.term_id
+		WHERE e.id = $1`,
+		enrollmentID, action, actor, reason)
+	return err
+}
+
+// PgRosterRepository manages offering rosters in Postgres.
+type PgRosterRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgRosterRepository(db *pgxpool.Pool) *PgRosterRepository {
+	return &PgRosterRepository{db: db}
+}
+
+func (r *PgRosterRepository) Roster(ctx context.Context, offeringID int) ([]RosterEntry, error) {
+	if err := offeringExists(ctx, r.db, offeringID); err != nil {
+		return nil, classify(err)
+	}
+	rows, err := r.db.Query(ctx, `
+		SELECT e.id, s.first_name, s.last_name, s.email, s.cohort, e.status,
+			CASE WHEN e.status = 'waitlisted'
+				THEN ROW_NUMBER() OVER (PARTITION BY e.status ORDER BY e.requested_at, e.id)
+				ELSE 0 END AS waitlist_position,
+			e.requested_at, e.enrolled_at
+		FROM enrollments e JOIN students s ON s.id = e.student_id
+		WHERE e.offering_id = $1 AND e.status <> 'dropped'
+		ORDER BY e.status = 'waitlisted', waitlist_position, s.last_name, s.first_name, s.email`,
+		offeringID)
+	if err != nil {
+		return nil, classify(err)
+	}
+	entries, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (RosterEntry, error) {
+		var e RosterEntry
+		err := row.Scan(&e.EnrollmentID, &e.FirstName, &e.LastName, &e.Email, &e.Cohort, &e.Status,
+			&e.WaitlistPosition, &e.RequestedAt, &e.EnrolledAt)
+		return e, err
+	})
+	return entries, classify(err)
+}
+
+func (r *PgRosterRepository) AddStudent(ctx context.Context, offeringID int, studentEmail, actor, reason string) (Enrollment, error) {
+	var e Enrollment
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := lockOffering(ctx, tx, offeringID); err != nil {
+			return err
+		}
+		studentID, err := studentIDByEmail(ctx, tx, studentEmail)
+		if err != nil {
+			return err
+		}
+
+		var (
+			id     int
+			status string
+		)
+		err = tx.QueryRow(ctx, `
+			SELECT id, status FROM enrollments
+			WHERE offering_id=$1 AND student_id=$2 AND status <> 'dropped'`,
+			offeringID, studentID,
+		).Scan(&id, &status)
+		switch {
+		case errors.Is(err, pgx.ErrNoRows):
+			err = tx.QueryRow(ctx, `
+				INSERT INTO enrollments (offering_id, student_id, status, enrolled_at)
+				VALUES ($1, $2, 'enrolled', NOW())
+				RETURNING id`,
+				offeringID, studentID,
+			).Scan(&id)
+		case err != nil:
+			return err
+		case status == Enrolled:
+			return ErrAlreadyEnrolled
+		default:
+			_, err = tx.Exec(ctx, `UPDATE enrollments SET status = 'enrolled', enrolled_at = NOW() WHERE id=$1`, id)
+		}
+		if err != nil {
+			return err
+		}
+
+		if err := recordRosterChange(ctx, tx, id, RosterAdded, actor, reason); err != nil {
+			return err
+		}
+		e, err = scanEnrollment(tx.QueryRow(ctx, enrollmentSelect+` WHERE e.id=$1`, id))
+		return err
+	})
+	return e, classify(err)
+}
+
+func (r *PgRosterRepository) RemoveStudent(ctx context.Context, offeringID, enrollmentID int, actor, reason string) (DropResult, error) {
+	var result DropResult
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := lockOffering(ctx, tx, offeringID); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE enrollments SET status = 'dropped', dropped_at = NOW()
+			WHERE id=$1 AND offering_id=$2 AND status <> 'dropped'`,
+			enrollmentID, offeringID)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrEnrollmentNotFound
+		}
+		if err := recordRosterChange(ctx, tx, enrollmentID, RosterRemoved, actor, reason); err != nil {
+			return err
+		}
+
+		result.Dropped, err = scanEnrollment(tx.QueryRow(ctx, enrollmentSelect+` WHERE e.id=$1`, enrollmentID))
+		if err != nil {
+			return err
+		}
+		result.Promoted, err = promoteWaitlist(ctx, tx, offeringID)
+		return err
+	})
+	return result, classify(err)
+}
+
+const rosterChangeSelect = `
+	SELECT id, offering_id, enrollment_id, student_email, term_code, course_code, section,
+		action, actor, reason, created_at
+	FROM roster_changes`
+
+func (r *PgRosterRepository) listRosterChanges(ctx context.Context, where string, args ...any) ([]RosterChange, error) {
+	rows, err := r.db.Query(ctx, rosterChangeSelect+`
+		WHERE `+where+`
+		ORDER BY created_at DESC, id DESC`, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (RosterChange, error) {
+		var c RosterChange
+		err := row.Scan(&c.ID, &c.OfferingID, &c.EnrollmentID, &c.StudentEmail, &c.TermCode, &c.CourseCode,
+			&c.Section, &c.Action, &c.Actor, &c.Reason, &c.CreatedAt)
+		return c, err
+	})
+}
+
+func (r *PgRosterRepository) RosterChanges(ctx context.Context, offeringID int) ([]RosterChange, error) {
+	if err := offeringExists(ctx, r.db, offeringID); err != nil {
+		return nil, classify(err)
+	}
+	changes, err := r.listRosterChanges(ctx, `offering_id = $1`, offeringID)
+	return changes, classify(err)
+}
+
+func (r *PgRosterRepository) TermRosterChanges(ctx context.Context, termID int, courseCode string) ([]RosterChange, error) {
+	if err := termExists(ctx, r.db, termID); err != nil {
+		return nil, classify(err)
+	}
+	changes, err := r.listRosterChanges(ctx, `term_id = $1 AND ($2 = '' OR course_code = $2)`, termID, courseCode)
+	return changes, classify(err)
+}
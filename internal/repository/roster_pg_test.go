@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgRosterRepository_ManualChanges(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	terms := NewPgTermRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	roster := NewPgRosterRepository(pool)
+	ctx := context.Background()
+
+	offering := createOffering(t, terms, pool, "CS101", 1)
+
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false); err != nil {
+		t.Fatalf("Enroll amani: %v", err)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "baraka@student.school.edu", true); err != nil {
+		t.Fatalf("Enroll baraka: %v", err)
+	}
+
+	// Staff may add students beyond capacity.
+	added, err := roster.AddStudent(ctx, offering.ID, "chege@student.school.edu", "ruth@school.edu", "Lab assistant")
+	if err != nil {
+		t.Fatalf("AddStudent: %v", err)
+	}
+	if added.Status != Enrolled {
+		t.Errorf("Expected chege enrolled, got %+v", added)
+	}
+	if _, err := roster.AddStudent(ctx, offering.ID, "chege@student.school.edu", "ruth@school.edu", ""); !errors.Is(err, ErrAlreadyEnrolled) {
+		t.Errorf("Expected ErrAlreadyEnrolled, got %v", err)
+	}
+
+	entries, err := roster.Roster(ctx, offering.ID)
+	if err != nil {
+		t.Fatalf("Roster: %v", err)
+	}
+	if len(entries) != 3 || entries[0].LastName != "Kamau" || entries[1].LastName != "Wanjiru" ||
+		entries[2].Status != Waitlisted || entries[2].WaitlistPosition != 1 {
+		t.Errorf("Expected two enrolled by name then the waitlist, got %+v", entries)
+	}
+
+	// Removing one of the two over capacity frees no seat.
+	result, err := roster.RemoveStudent(ctx, offering.ID, entries[1].EnrollmentID, "ruth@school.edu", "Never attended")
+	if err != nil {
+		t.Fatalf("RemoveStudent: %v", err)
+	}
+	if result.Dropped.StudentEmail != "amani@student.school.edu" || len(result.Promoted) != 0 {
+		t.Errorf("Expected amani dropped and nobody promoted, got %+v", result)
+	}
+	if _, err := roster.RemoveStudent(ctx, offering.ID, entries[1].EnrollmentID, "ruth@school.edu", ""); !errors.Is(err, ErrEnrollmentNotFound) {
+		t.Errorf("Expected ErrEnrollmentNotFound removing twice, got %v", err)
+	}
+
+	changes, err := roster.RosterChanges(ctx, offering.ID)
+	if err != nil {
+		t.Fatalf("RosterChanges: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Action != RosterRemoved || changes[0].Reason != "Never attended" ||
+		changes[1].Action != RosterAdded || changes[1].Actor != "ruth@school.edu" {
+		t.Errorf("Expected the removal and the addition audited, got %+v", changes)
+	}
+	if c := changes[0]; c.StudentEmail != "amani@student.school.edu" || c.TermCode != "NOW" || c.CourseCode != "CS101" || c.Section != "A" {
+		t.Errorf("Expected the change to name the student and offering, got %+v", c)
+	}
+
+	if _, err := roster.Roster(ctx, offering.ID+1000); !errors.Is(err, ErrOfferingNotFound) {
+		t.Errorf("Expected ErrOfferingNotFound, got %v", err)
+	}
+}
+
+func TestPgRosterRepository_ChangesOutliveOffering(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	terms := NewPgTermRepository(pool)
+	roster := NewPgRosterRepository(pool)
+	ctx := context.Background()
+
+	offering := createOffering(t, terms, pool, "CS101", 10)
+	added, err := roster.AddStudent(ctx, offering.ID, "amani@student.school.edu", "ruth@school.edu", "")
+	if err != nil {
+		t.Fatalf("AddStudent: %v", err)
+	}
+	if _, err := roster.RemoveStudent(ctx, offering.ID, added.ID, "ruth@school.edu", "Wrong section"); err != nil {
+		t.Fatalf("RemoveStudent: %v", err)
+	}
+	if err := terms.DeleteOffering(ctx, offering.ID); err != nil {
+		t.Fatalf("DeleteOffering: %v", err)
+	}
+
+	var (
+		kept       int
+		email      string
+		offeringID *int
+	)
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*), MIN(student_email), MIN(offering_id) FROM roster_changes WHERE course_code = 'CS101'`,
+	).Scan(&kept, &email, &offeringID)
+	if err != nil {
+		t.Fatalf("count roster changes: %v", err)
+	}
+	if kept != 2 || email != "amani@student.school.edu" || offeringID != nil {
+		t.Errorf("Expected both changes kept with their student and no offering, got %d %q %v", kept, email, offeringID)
+	}
+
+	// The offering is gone, but its term still lists the changes.
+	changes, err := roster.TermRosterChanges(ctx, offering.TermID, "CS101")
+	if err != nil {
+		t.Fatalf("TermRosterChanges: %v", err)
+	}
+	if len(changes) != 2 || changes[0].Action != RosterRemoved || changes[0].OfferingID != nil || changes[0].Section != "A" {
+		t.Errorf("Expected both changes of the deleted offering, newest first, got %+v", changes)
+	}
+	if changes, _ := roster.TermRosterChanges(ctx, offering.TermID, "MA101"); len(changes) != 0 {
+		t.Errorf("Expected no changes for another course, got %+v", changes)
+	}
+	if _, err := roster.TermRosterChanges(ctx, 0, ""); !errors.Is(err, ErrTermNotFound) {
+		t.Errorf("Expected ErrTermNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,48 @@
+package repository
+
+// CourseSearch selects courses from the catalog. Zero fields do not
+// filter; Credits is a pointer because courses may be worth no credits.
+// The offering filters (TermID, Instructor, OpenSeats) must all hold for
+// the same offering.
+type CourseSearch struct {
+	// Query is free text in web search syntax: quoted phrases, OR, and -
+	// to exclude a word.
+	Query      string
+	Department string
+	Credits    *int
+
+	TermID     int
+	Instructor string
+	OpenSeats  bool
+
+	Page Page
+}
+
+// FacetCount is the number of matching courses sharing a value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+// CourseFacets break the courses matching a search down by attribute.
+// Term, instructor and open-seat counts only consider offerings in the
+// searched term, if any.
+type CourseFacets struct {
+	Departments []FacetCount `json:"departments"`
+	Credits     []FacetCount `json:"credits"`
+	Terms       []FacetCount `json:"terms"`
+	Instructors []FacetCount `json:"instructors"`
+
+	// OpenSeats counts courses with at least one offering that has a free
+	// seat.
+	OpenSeats int `json:"open_seats"`
+}
+
+// CourseSearchResult is a page of matching courses, best match first, with
+// facets over all matches.
+type CourseSearchResult struct {
+	Courses []Course
+	Total   int
+	Facets  CourseFacets
+}
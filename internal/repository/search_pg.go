@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// matchedCourses selects the IDs and ranks of the courses matching a
+// search. Its parameters are the CourseSearch filters in searchArgs order.
+const matchedCourses = `
+	WITH matched AS (
+		SELECT c.id, ts_rank(c.search, q.query) AS rank
+		FROM courses c, websearch_to_tsquery('english', $1::text) AS q(query)
+		WHERE ($1::text = '' OR c.search @@ q.query)
+			AND ($2::text = '' OR c.department = $2::text)
+			AND ($3::int IS NULL OR c.credits = $3::int)
+			AND (($4::int = 0 AND $5::text = '' AND NOT $6::bool) OR EXISTS (
+				SELECT 1
+				FROM course_offerings o JOIN offering_seats s ON s.offering_id = o.id
+				WHERE o.course_id = c.id
+					AND ($4::int = 0 OR o.term_id = $4::int)
+					AND ($5::text = '' OR EXISTS (
+						SELECT 1
+						FROM offering_instructors oi JOIN staff st ON st.id = oi.staff_id
+						WHERE oi.offering_id = o.id AND st.email = $5::text))
+					AND (NOT $6::bool OR s.seats_open > 0)))
+	)`
+
+// matchedOfferings joins matched courses to their offerings in the
+// searched term.
+const matchedOfferings = `
+	matched m
+	JOIN course_offerings o ON o.course_id = m.id AND ($4::int = 0 OR o.term_id = $4::int)`
+
+func searchArgs(s CourseSearch) []any {
+	return []any{s.Query, s.Department, s.Credits, s.TermID, s.Instructor, s.OpenSeats}
+}
+
+func (r *PgCourseRepository) SearchCourses(ctx context.Context, s CourseSearch) (CourseSearchResult, error) {
+	result := CourseSearchResult{Courses: []Course{}}
+	args := searchArgs(s)
+
+	// Repeatable read keeps the page, total and facets consistent with
+	// each other.
+	err := pgx.BeginTxFunc(ctx, r.db, pgx.TxOptions{IsoLevel: pgx.RepeatableRead, AccessMode: pgx.ReadOnly}, func(tx pgx.Tx) error {
+		if err := tx.QueryRow(ctx, matchedCourses+` SELECT COUNT(*) FROM matched`, args...).Scan(&result.Total); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, matchedCourses+`
+			SELECT c.id, c.code, c.title, c.description, c.credits, c.department, c.created_at, c.updated_at
+			FROM matched m JOIN courses c ON c.id = m.id
+			ORDER BY m.rank DESC, c.code
+			LIMIT $7 OFFSET $8`,
+			append(searchArgs(s), s.Page.Limit, s.Page.Offset)...)
+		if err != nil {
+			return err
+		}
+		result.Courses, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (Course, error) {
+			return scanCourse(row)
+		})
+		if err != nil {
+			return err
+		}
+		if err := loadOwners(ctx, tx, result.Courses); err != nil {
+			return err
+		}
+
+		result.Facets, err = searchFacets(ctx, tx, args)
+		return err
+	})
+	return result, classify(err)
+}
+
+func searchFacets(ctx context.Context, tx pgx.Tx, args []any) (CourseFacets, error) {
+	var (
+		f   CourseFacets
+		err error
+	)
+
+	f.Departments, err = facetCounts(ctx, tx, matchedCourses+`
+		SELECT c.department, '', COUNT(*)
+		FROM matched m JOIN courses c ON c.id = m.id
+		GROUP BY c.department
+		ORDER BY COUNT(*) DESC, c.department`, args)
+	if err != nil {
+		return f, err
+	}
+
+	f.Credits, err = facetCounts(ctx, tx, matchedCourses+`
+		SELECT c.credits::text, '', COUNT(*)
+		FROM matched m JOIN courses c ON c.id = m.id
+		GROUP BY c.credits
+		ORDER BY c.credits`, args)
+	if err != nil {
+		return f, err
+	}
+
+	f.Terms, err = facetCounts(ctx, tx, matchedCourses+`
+		SELECT t.id::text, t.code, COUNT(DISTINCT m.id)
+		FROM `+matchedOfferings+`
+		JOIN terms t ON t.id = o.term_id
+		GROUP BY t.id
+		ORDER BY t.starts_on DESC`, args)
+	if err != nil {
+		return f, err
+	}
+
+	f.Instructors, err = facetCounts(ctx, tx, matchedCourses+`
+		SELECT st.email, st.first_name || ' ' || st.last_name, COUNT(DISTINCT m.id)
+		FROM `+matchedOfferings+`
+		JOIN offering_instructors oi ON oi.offering_id = o.id
+		JOIN staff st ON st.id = oi.staff_id
+		GROUP BY st.id
+		ORDER BY COUNT(DISTINCT m.id) DESC, st.email`, args)
+	if err != nil {
+		return f, err
+	}
+
+	err = tx.QueryRow(ctx, matchedCourses+`
+		SELECT COUNT(DISTINCT m.id)
+		FROM `+matchedOfferings+`
+		JOIN offering_seats s ON s.offering_id = o.id
+		WHERE s.seats_open > 0`, args...).Scan(&f.OpenSeats)
+	return f, err
+}
+
+func facetCounts(ctx context.Context, tx pgx.Tx, sql string, args []any) ([]FacetCount, error) {
+	rows, err := tx.Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (FacetCount, error) {
+		var fc FacetCount
+		err := row.Scan(&fc.Value, &fc.Label, &fc.Count)
+		return fc, err
+	})
+}
@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgCourseRepository_Search(t *testing.T) {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO staff (first_name, last_name, email, role) VALUES
+			('Ruth', 'Njeri', 'ruth@school.edu', 'teacher');
+		INSERT INTO courses (code, title, description, credits, department) VALUES
+			('CS101', 'Intro to Programming', 'Variables, loops and functions', 3, 'CS'),
+			('CS240', 'Functional Programming', 'Higher-order functions and types', 4, 'CS'),
+			('MATH110', 'Linear Algebra', 'Vectors and matrices, with a programming lab', 4, 'Mathematics'),
+			('HIST100', 'World History', 'Empires and revolutions', 3, 'History'),
+			('SEM000', 'Research Seminar', 'Weekly talks by visiting speakers', 0, 'CS')`)
+
+	courses := NewPgCourseRepository(pool)
+	terms := NewPgTermRepository(pool)
+	ctx := context.Background()
+
+	start := time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC)
+	term := createTerm(t, terms, "2026-S2", start)
+
+	cs240 := courseIDByCode(t, pool, "CS240")
+	math110 := courseIDByCode(t, pool, "MATH110")
+	if _, err := terms.CreateOffering(ctx, Offering{TermID: term.ID, CourseID: cs240, Section: "A", Capacity: 30}, []string{"ruth@school.edu"}); err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+	if _, err := terms.CreateOffering(ctx, Offering{TermID: term.ID, CourseID: math110, Section: "A", Capacity: 0}, []string{}); err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+
+	result, err := courses.SearchCourses(ctx, CourseSearch{Query: "programming", Page: Page{Limit: 2}})
+	if err != nil {
+		t.Fatalf("SearchCourses: %v", err)
+	}
+	if result.Total != 3 || len(result.Courses) != 2 {
+		t.Fatalf("Expected a page of 2 out of 3 matches, got %d %+v", result.Total, result.Courses)
+	}
+	if code := result.Courses[0].Code; code != "CS101" && code != "CS240" {
+		t.Errorf("Expected a title match to rank first, got %s", code)
+	}
+	if len(result.Facets.Departments) != 2 || result.Facets.Departments[0].Value != "CS" || result.Facets.Departments[0].Count != 2 {
+		t.Errorf("Expected department facets CS:2 and Mathematics:1, got %+v", result.Facets.Departments)
+	}
+	if result.Facets.OpenSeats != 1 {
+		t.Errorf("Expected one course with open seats, got %d", result.Facets.OpenSeats)
+	}
+
+	result, err = courses.SearchCourses(ctx, CourseSearch{TermID: term.ID, OpenSeats: true, Page: Page{Limit: 10}})
+	if err != nil {
+		t.Fatalf("SearchCourses: %v", err)
+	}
+	if result.Total != 1 || result.Courses[0].Code != "CS240" {
+		t.Errorf("Expected only CS240 to have open seats in the term, got %+v", result.Courses)
+	}
+	if len(result.Facets.Instructors) != 1 || result.Facets.Instructors[0].Value != "ruth@school.edu" {
+		t.Errorf("Expected ruth as the only instructor facet, got %+v", result.Facets.Instructors)
+	}
+
+	four := 4
+	result, err = courses.SearchCourses(ctx, CourseSearch{Query: "history", Credits: &four, Page: Page{Limit: 10}})
+	if err != nil {
+		t.Fatalf("SearchCourses: %v", err)
+	}
+	if result.Total != 0 || len(result.Courses) != 0 {
+		t.Errorf("Expected no 4-credit history course, got %+v", result.Courses)
+	}
+
+	zero := 0
+	result, err = courses.SearchCourses(ctx, CourseSearch{Credits: &zero, Page: Page{Limit: 10}})
+	if err != nil {
+		t.Fatalf("SearchCourses: %v", err)
+	}
+	if result.Total != 1 || result.Courses[0].Code != "SEM000" {
+		t.Errorf("Expected credits=0 to find only the zero-credit seminar, got %+v", result.Courses)
+	}
+}
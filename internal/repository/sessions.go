@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"strings"
+	"sync"
+
+	"elimu-go/internal/models"
+)
+
+// SessionRepository stores logged in users by session ID.
+type SessionRepository interface {
+	Get(id string) (*models.User, bool)
+	Save(id string, u *models.User)
+	Delete(id string)
+
+	// List returns a snapshot of every active session's user.
+	List() []models.User
+
+	// UpdateRole changes the role of every session belonging to email.
+	UpdateRole(email, role string)
+}
+
+// MemorySessionRepository keeps sessions in process memory. Sessions are
+// lost on restart.
+type MemorySessionRepository struct {
+	sessions sync.Map
+}
+
+func NewMemorySessionRepository() *MemorySessionRepository {
+	return &MemorySessionRepository{}
+}
+
+func (r *MemorySessionRepository) Get(id string) (*models.User, bool) {
+	v, ok := r.sessions.Load(id)
+	if !ok {
+		return nil, false
+	}
+	u, ok := v.(*models.User)
+	return u, ok
+}
+
+func (r *MemorySessionRepository) Save(id string, u *models.User) {
+	r.sessions.Store(id, u)
+}
+
+func (r *MemorySessionRepository) Delete(id string) {
+	r.sessions.Delete(id)
+}
+
+func (r *MemorySessionRepository) List() []models.User {
+	var users []models.User
+	r.sessions.Range(func(_, value any) bool {
+		if u, ok := value.(*models.User); ok {
+			users = append(users, *u)
+		}
+		return true
+	})
+	return users
+}
+
+// UpdateRole replaces matching session users rather than mutating them, so
+// concurrent readers holding the old pointer never see a torn write.
+func (r *MemorySessionRepository) UpdateRole(email, role string) {
+	r.sessions.Range(func(key, value any) bool {
+		if u, ok := value.(*models.User); ok && strings.EqualFold(u.Email, email) {
+			updated := *u
+			updated.Role = role
+			r.sessions.Store(key, &updated)
+		}
+		return true
+	})
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"testing"
+
+	"elimu-go/internal/models"
+)
+
+func TestMemorySessionRepository_UpdateRole(t *testing.T) {
+	r := NewMemorySessionRepository()
+
+	old := &models.User{ID: "1", Email: "Teacher@School.edu", Role: "admin"}
+	r.Save("session_1", old)
+	r.Save("session_2", &models.User{ID: "2", Email: "other@school.edu", Role: "admin"})
+
+	r.UpdateRole("teacher@school.edu", "teacher")
+
+	u, _ := r.Get("session_1")
+	if u.Role != "teacher" {
+		t.Errorf("Expected session role to be updated to teacher, got %q", u.Role)
+	}
+	if old.Role != "admin" {
+		t.Error("UpdateRole should not mutate users already handed out")
+	}
+
+	other, _ := r.Get("session_2")
+	if other.Role != "admin" {
+		t.Errorf("Other sessions should be untouched, got %q", other.Role)
+	}
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTermNotFound     = fmt.Errorf("term %w", ErrNotFound)
+	ErrTermCodeTaken    = fmt.Errorf("%w: term code is already in use", ErrConflict)
+	ErrOfferingNotFound = fmt.Errorf("course offering %w", ErrNotFound)
+	ErrSectionTaken     = fmt.Errorf("%w: the course already has this section in the term", ErrConflict)
+
+	ErrOfferingHasEnrollments = fmt.Errorf("%w: students are still enrolled or waitlisted in the offering", ErrConflict)
+)
+
+// Term is a teaching period such as a semester.
+type Term struct {
+	ID   int    `json:"id"`
+	Code string `json:"code"`
+	Name string `json:"name"`
+
+	// First and last day of teaching.
+	StartsOn time.Time `json:"starts_on"`
+	EndsOn   time.Time `json:"ends_on"`
+
+	EnrollmentOpensAt  time.Time `json:"enrollment_opens_at"`
+	EnrollmentClosesAt time.Time `json:"enrollment_closes_at"`
+
+	// AddDropDeadline is the last moment students may drop without record.
+	AddDropDeadline time.Time `json:"add_drop_deadline"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// Offering is a section of a course taught in a term.
+type Offering struct {
+	ID          int    `json:"id"`
+	TermID      int    `json:"term_id"`
+	CourseID    int    `json:"course_id"`
+	CourseCode  string `json:"course_code"`
+	CourseTitle string `json:"course_title"`
+	Section     string `json:"section"`
+	Capacity    int    `json:"capacity"`
+
+	Instructors []Instructor `json:"instructors"`
+	Meetings    []Meeting    `json:"meetings"`
+	CreatedAt   time.Time    `json:"created_at"`
+	UpdatedAt   time.Time    `json:"updated_at"`
+}
+
+// IsInstructor reports whether email belongs to one of the offering's
+// instructors, ignoring case.
+func (o Offering) IsInstructor(email string) bool {
+	for _, i := range o.Instructors {
+		if strings.EqualFold(i.Email, email) {
+			return true
+		}
+	}
+	return false
+}
+
+// Instructor is a staff member teaching an offering.
+type Instructor struct {
+	StaffID   int    `json:"staff_id"`
+	Email     string `json:"email"`
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+}
+
+// Meeting is a weekly class meeting. Times are wall-clock "HH:MM".
+type Meeting struct {
+	// ISO weekday: 1 is Monday, 7 is Sunday.
+	Weekday  int    `json:"weekday"`
+	StartsAt string `json:"starts_at"`
+	EndsAt   string `json:"ends_at"`
+	Room     string `json:"room"`
+}
+
+// CloneResult reports what cloning a term's offerings did.
+type CloneResult struct {
+	Created int `json:"created"`
+
+	// Skipped counts offerings whose course and section already existed in
+	// the target term.
+	Skipped int `json:"skipped"`
+
+	// Clashes lists offerings left out because a room they meet in is
+	// already booked at the same time in the target term.
+	Clashes []CloneClash `json:"clashes"`
+}
+
+// CloneClash is an offering that could not be cloned because one of its
+// meetings needs a room that With already has.
+type CloneClash struct {
+	OfferingID int              `json:"offering_id"`
+	CourseCode string           `json:"course_code"`
+	Section    string           `json:"section"`
+	Meeting    Meeting          `json:"meeting"`
+	With       ScheduledMeeting `json:"with"`
+}
+
+// TermRepository stores terms and the course offerings in them.
+type TermRepository interface {
+	CreateTerm(ctx context.Context, t Term) (Term, error)
+	GetTerm(ctx context.Context, id int) (Term, error)
+
+	// ListTerms returns every term, latest first.
+	ListTerms(ctx context.Context) ([]Term, error)
+	UpdateTerm(ctx context.Context, t Term) (Term, error)
+
+	// CreateOffering stores o taught by the staff with instructorEmails,
+	// returning ErrStaffNotFound if any of them is not staff and a
+	// ClashError if a meeting's room is booked by another offering then.
+	CreateOffering(ctx context.Context, o Offering, instructorEmails []string) (Offering, error)
+	GetOffering(ctx context.Context, id int) (Offering, error)
+
+	// ListOfferings returns the term's offerings ordered by course code and
+	// section.
+	ListOfferings(ctx context.Context, termID int) ([]Offering, error)
+
+	// UpdateOffering replaces the offering's section, capacity and
+	// meetings, checking rooms as CreateOffering does. A nil
+	// instructorEmails keeps the current instructors.
+	UpdateOffering(ctx context.Context, o Offering, instructorEmails []string) (Offering, error)
+
+	// DeleteOffering removes an offering, returning
+	// ErrOfferingHasEnrollments while students are enrolled or waitlisted
+	// in it.
+	DeleteOffering(ctx context.Context, id int) error
+
+	// CloneOfferings copies every offering of one term, with its
+	// instructors and meetings, into another. Offerings whose rooms are
+	// booked in the target term are reported in Clashes, not cloned.
+	CloneOfferings(ctx context.Context, fromTermID, toTermID int) (CloneResult, error)
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"slices"
+	"sync"
+	"time"
+)
+
+// MemoryTermRepository is an in-memory TermRepository for tests. Offerings
+// are of courses in the MemoryCourseRepository it was created with and are
+// taught by staff of its MemoryUserRepository. It keeps no enrollments, so
+// DeleteOffering always succeeds.
+type MemoryTermRepository struct {
+	mu        sync.RWMutex
+	courses   *MemoryCourseRepository
+	terms     []Term
+	offerings []Offering
+	nextID    int
+
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func NewMemoryTermRepository(courses *MemoryCourseRepository) *MemoryTermRepository {
+	return &MemoryTermRepository{courses: courses}
+}
+
+func (r *MemoryTermRepository) id() int {
+	r.nextID++
+	return r.nextID
+}
+
+func (r *MemoryTermRepository) findTerm(id int) int {
+	return slices.IndexFunc(r.terms, func(t Term) bool { return t.ID == id })
+}
+
+func (r *MemoryTermRepository) findOffering(id int) int {
+	return slices.IndexFunc(r.offerings, func(o Offering) bool { return o.ID == id })
+}
+
+// codeTaken reports whether a term other than id already uses code.
+func (r *MemoryTermRepository) codeTaken(code string, id int) bool {
+	return slices.ContainsFunc(r.terms, func(t Term) bool { return t.Code == code && t.ID != id })
+}
+
+// sectionTaken reports whether an offering other than o has o's course
+// and section in its term.
+func (r *MemoryTermRepository) sectionTaken(o Offering) bool {
+	return slices.ContainsFunc(r.offerings, func(other Offering) bool {
+		return other.TermID == o.TermID && other.CourseID == o.CourseID && other.Section == o.Section && other.ID != o.ID
+	})
+}
+
+// instructors resolves instructorEmails to staff, as setInstructors does.
+func (r *MemoryTermRepository) instructors(instructorEmails []string) ([]Instructor, error) {
+	instructors := []Instructor{}
+	for _, email := range instructorEmails {
+		s, id, ok := r.courses.users.staffMember(email)
+		if !ok {
+			return nil, ErrStaffNotFound
+		}
+		instructors = append(instructors, Instructor{StaffID: id, Email: s.Email, FirstName: s.FirstName, LastName: s.LastName})
+	}
+	return instructors, nil
+}
+
+// checkRooms mirrors checkRoomClashes.
+func (r *MemoryTermRepository) checkRooms(termID, offeringID int, meetings []Meeting) error {
+	var existing []ScheduledMeeting
+	for _, o := range r.offerings {
+		if o.TermID != termID || o.ID == offeringID {
+			continue
+		}
+		for _, m := range o.Meetings {
+			existing = append(existing, ScheduledMeeting{OfferingID: o.ID, CourseCode: o.CourseCode, Section: o.Section, Meeting: m})
+		}
+	}
+	return checkClashes(ErrRoomBooked, meetings, existing)
+}
+
+// sortMeetings orders meetings by weekday and start, as they are loaded.
+func sortMeetings(meetings []Meeting) []Meeting {
+	meetings = append([]Meeting{}, meetings...)
+	slices.SortFunc(meetings, func(a, b Meeting) int {
+		return cmp.Or(cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(a.StartsAt, b.StartsAt))
+	})
+	return meetings
+}
+
+func (r *MemoryTermRepository) CreateTerm(_ context.Context, t Term) (Term, error) {
+	if r.Err != nil {
+		return t, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.codeTaken(t.Code, 0) {
+		return t, ErrTermCodeTaken
+	}
+	t.ID = r.id()
+	t.CreatedAt = time.Now()
+	r.terms = append(r.terms, t)
+	return t, nil
+}
+
+func (r *MemoryTermRepository) GetTerm(_ context.Context, id int) (Term, error) {
+	if r.Err != nil {
+		return Term{}, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.findTerm(id)
+	if i < 0 {
+		return Term{}, ErrTermNotFound
+	}
+	return r.terms[i], nil
+}
+
+func (r *MemoryTermRepository) ListTerms(context.Context) ([]Term, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	terms := append([]Term{}, r.terms...)
+	slices.SortFunc(terms, func(a, b Term) int { return b.StartsOn.Compare(a.StartsOn) })
+	return terms, nil
+}
+
+func (r *MemoryTermRepository) UpdateTerm(_ context.Context, t Term) (Term, error) {
+	if r.Err != nil {
+		return t, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.findTerm(t.ID)
+	if i < 0 {
+		return t, ErrTermNotFound
+	}
+	if r.codeTaken(t.Code, t.ID) {
+		return t, ErrTermCodeTaken
+	}
+	t.CreatedAt = r.terms[i].CreatedAt
+	r.terms[i] = t
+	return t, nil
+}
+
+// addOffering stores o in its term, checking it as CreateOffering does.
+func (r *MemoryTermRepository) addOffering(o Offering, instructors []Instructor) (Offering, error) {
+	if r.findTerm(o.TermID) < 0 {
+		return o, ErrTermNotFound
+	}
+	course, ok := r.courses.course(o.CourseID)
+	if !ok {
+		return o, ErrCourseNotFound
+	}
+	if r.sectionTaken(o) {
+		return o, ErrSectionTaken
+	}
+	if err := r.checkRooms(o.TermID, 0, o.Meetings); err != nil {
+		return o, err
+	}
+
+	o.ID = r.id()
+	o.CourseCode = course.Code
+	o.CourseTitle = course.Title
+	o.Instructors = instructors
+	o.Meetings = sortMeetings(o.Meetings)
+	o.CreatedAt = time.Now()
+	o.UpdatedAt = o.CreatedAt
+	r.offerings = append(r.offerings, o)
+	return o, nil
+}
+
+func (r *MemoryTermRepository) CreateOffering(_ context.Context, o Offering, instructorEmails []string) (Offering, error) {
+	if r.Err != nil {
+		return o, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	instructors, err := r.instructors(instructorEmails)
+	if err != nil {
+		return o, err
+	}
+	return r.addOffering(o, instructors)
+}
+
+func (r *MemoryTermRepository) GetOffering(_ context.Context, id int) (Offering, error) {
+	if r.Err != nil {
+		return Offering{}, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	i := r.findOffering(id)
+	if i < 0 {
+		return Offering{}, ErrOfferingNotFound
+	}
+	return r.offerings[i], nil
+}
+
+func (r *MemoryTermRepository) ListOfferings(_ context.Context, termID int) ([]Offering, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if r.findTerm(termID) < 0 {
+		return nil, ErrTermNotFound
+	}
+	offerings := []Offering{}
+	for _, o := range r.offerings {
+		if o.TermID == termID {
+			offerings = append(offerings, o)
+		}
+	}
+	slices.SortFunc(offerings, func(a, b Offering) int {
+		return cmp.Or(cmp.Compare(a.CourseCode, b.CourseCode), cmp.Compare(a.Section, b.Section))
+	})
+	return offerings, nil
+}
+
+func (r *MemoryTermRepository) UpdateOffering(_ context.Context, o Offering, instructorEmails []string) (Offering, error) {
+	if r.Err != nil {
+		return o, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.findOffering(o.ID)
+	if i < 0 {
+		return o, ErrOfferingNotFound
+	}
+	current := r.offerings[i]
+	if err := r.checkRooms(current.TermID, o.ID, o.Meetings); err != nil {
+		return o, err
+	}
+	updated := current
+	updated.Section = o.Section
+	if r.sectionTaken(updated) {
+		return o, ErrSectionTaken
+	}
+	if instructorEmails != nil {
+		instructors, err := r.instructors(instructorEmails)
+		if err != nil {
+			return o, err
+		}
+		updated.Instructors = instructors
+	}
+	updated.Capacity = o.Capacity
+	updated.Meetings = sortMeetings(o.Meetings)
+	updated.UpdatedAt = time.Now()
+	r.offerings[i] = updated
+	return updated, nil
+}
+
+func (r *MemoryTermRepository) DeleteOffering(_ context.Context, id int) error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := r.findOffering(id)
+	if i < 0 {
+		return ErrOfferingNotFound
+	}
+	r.offerings = slices.Delete(r.offerings, i, i+1)
+	return nil
+}
+
+func (r *MemoryTermRepository) CloneOfferings(_ context.Context, fromTermID, toTermID int) (CloneResult, error) {
+	result := CloneResult{Clashes: []CloneClash{}}
+	if r.Err != nil {
+		return result, r.Err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.findTerm(fromTermID) < 0 || r.findTerm(toTermID) < 0 {
+		return result, ErrTermNotFound
+	}
+	var sources []Offering
+	for _, o := range r.offerings {
+		if o.TermID == fromTermID {
+			sources = append(sources, o)
+		}
+	}
+
+	for _, s := range sources {
+		clone := s
+		clone.TermID = toTermID
+		_, err := r.addOffering(clone, slices.Clone(s.Instructors))
+		var clash *ClashError
+		switch {
+		case errors.Is(err, ErrSectionTaken):
+			result.Skipped++
+		case errors.As(err, &clash):
+			result.Clashes = append(result.Clashes, CloneClash{
+				OfferingID: s.ID,
+				CourseCode: s.CourseCode,
+				Section:    s.Section,
+				Meeting:    clash.Clash.Meeting,
+				With:       clash.Clash.With,
+			})
+		case err != nil:
+			return result, err
+		default:
+			result.Created++
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,461 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const termColumns = `id, code, name, starts_on, ends_on, enrollment_opens_at,
+	enrollment_closes_at, add_drop_deadline, created_at`
+
+func scanTerm(row pgx.Row) (Term, error) {
+	var t Term
+	err := row.Scan(&t.ID, &t.Code, &t.Name, &t.StartsOn, &t.EndsOn, &t.EnrollmentOpensAt,
+		&t.EnrollmentClosesAt, &t.AddDropDeadline, &t.CreatedAt)
+	return t, err
+}
+
+func termError(err error) error {
+	if pgCode(err) == uniqueViolation {
+		return ErrTermCodeTaken
+	}
+	return err
+}
+
+const offeringSelect = `
+	SELECT o.id, o.term_id, o.course_id, c.code, c.title, o.section, o.capacity,
+		o.created_at, o.updated_at
+	FROM course_offerings o JOIN courses c ON c.id = o.course_id`
+
+func scanOffering(row pgx.Row) (Offering, error) {
+	var o Offering
+	err := row.Scan(&o.ID, &o.TermID, &o.CourseID, &o.CourseCode, &o.CourseTitle,
+		&o.Section, &o.Capacity, &o.CreatedAt, &o.UpdatedAt)
+	return o, err
+}
+
+func offeringError(err error) error {
+	if pgCode(err) == uniqueViolation {
+		return ErrSectionTaken
+	}
+	return err
+}
+
+func termExists(ctx context.Context, db dbtx, termID int) error {
+	var exists bool
+	err := db.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM terms WHERE id=$1)`, termID).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrTermNotFound
+	}
+	return nil
+}
+
+// loadOfferingDetails fills in the instructors and meetings of each
+// offering.
+func loadOfferingDetails(ctx context.Context, db dbtx, offerings []Offering) error {
+	if len(offerings) == 0 {
+		return nil
+	}
+
+	ids := make([]int, len(offerings))
+	index := make(map[int]int, len(offerings))
+	for i := range offerings {
+		ids[i] = offerings[i].ID
+		index[offerings[i].ID] = i
+		offerings[i].Instructors = []Instructor{}
+		offerings[i].Meetings = []Meeting{}
+	}
+
+	rows, err := db.Query(ctx, `
+		SELECT oi.offering_id, s.id, s.email, s.first_name, s.last_name
+		FROM offering_instructors oi JOIN staff s ON s.id = oi.staff_id
+		WHERE oi.offering_id = ANY($1)
+		ORDER BY s.email`, ids)
+	if err != nil {
+		return err
+	}
+	for rows.Next() {
+		var (
+			offeringID int
+			in         Instructor
+		)
+		if err := rows.Scan(&offeringID, &in.StaffID, &in.Email, &in.FirstName, &in.LastName); err != nil {
+			rows.Close()
+			return err
+		}
+		o := &offerings[index[offeringID]]
+		o.Instructors = append(o.Instructors, in)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	rows, err = db.Query(ctx, `
+		SELECT offering_id, weekday, to_char(starts_at, 'HH24:MI'), to_char(ends_at, 'HH24:MI'), room
+		FROM offering_meetings
+		WHERE offering_id = ANY($1)
+		ORDER BY weekday, starts_at`, ids)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var (
+			offeringID int
+			m          Meeting
+		)
+		if err := rows.Scan(&offeringID, &m.Weekday, &m.StartsAt, &m.EndsAt, &m.Room); err != nil {
+			return err
+		}
+		o := &offerings[index[offeringID]]
+		o.Meetings = append(o.Meetings, m)
+	}
+	return rows.Err()
+}
+
+func setInstructors(ctx context.Context, tx pgx.Tx, offeringID int, emails []string) error {
+	ids, err := staffIDs(ctx, tx, emails)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM offering_instructors WHERE offering_id=$1`, offeringID); err != nil {
+		return err
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO offering_instructors (offering_id, staff_id)
+		SELECT $1, unnest($2::int[])
+		ON CONFLICT DO NOTHING`,
+		offeringID, ids)
+	return err
+}
+
+func setMeetings(ctx context.Context, tx pgx.Tx, offeringID int, meetings []Meeting) error {
+	if _, err := tx.Exec(ctx, `DELETE FROM offering_meetings WHERE offering_id=$1`, offeringID); err != nil {
+		return err
+	}
+
+	weekdays := make([]int, len(meetings))
+	starts := make([]string, len(meetings))
+	ends := make([]string, len(meetings))
+	rooms := make([]string, len(meetings))
+	for i, m := range meetings {
+		weekdays[i], starts[i], ends[i], rooms[i] = m.Weekday, m.StartsAt, m.EndsAt, m.Room
+	}
+	_, err := tx.Exec(ctx, `
+		INSERT INTO offering_meetings (offering_id, weekday, starts_at, ends_at, room)
+		SELECT $1, m.weekday, m.starts_at::time, m.ends_at::time, m.room
+		FROM unnest($2::int[], $3::text[], $4::text[], $5::text[]) AS m(weekday, starts_at, ends_at, room)`,
+		offeringID, weekdays, starts, ends, rooms)
+	return err
+}
+
+// PgTermRepository stores terms and offerings in Postgres.
+type PgTermRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgTermRepository(db *pgxpool.Pool) *PgTermRepository {
+	return &PgTermRepository{db: db}
+}
+
+func (r *PgTermRepository) CreateTerm(ctx context.Context, t Term) (Term, error) {
+	t, err := scanTerm(r.db.QueryRow(ctx, `
+		INSERT INTO terms (code, name, starts_on, ends_on, enrollment_opens_at,
+			enrollment_closes_at, add_drop_deadline)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		RETURNING `+termColumns,
+		t.Code, t.Name, t.StartsOn, t.EndsOn, t.EnrollmentOpensAt,
+		t.EnrollmentClosesAt, t.AddDropDeadline))
+	return t, classify(termError(err))
+}
+
+func (r *PgTermRepository) GetTerm(ctx context.Context, id int) (Term, error) {
+	t, err := scanTerm(r.db.QueryRow(ctx, `SELECT `+termColumns+` FROM terms WHERE id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return t, ErrTermNotFound
+	}
+	return t, classify(err)
+}
+
+func (r *PgTermRepository) ListTerms(ctx context.Context) ([]Term, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+termColumns+` FROM terms ORDER BY starts_on DESC`)
+	if err != nil {
+		return nil, classify(err)
+	}
+	terms, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Term, error) {
+		return scanTerm(row)
+	})
+	return terms, classify(err)
+}
+
+func (r *PgTermRepository) UpdateTerm(ctx context.Context, t Term) (Term, error) {
+	t, err := scanTerm(r.db.QueryRow(ctx, `
+		UPDATE terms
+		SET code=$2, name=$3, starts_on=$4, ends_on=$5, enrollment_opens_at=$6,
+			enrollment_closes_at=$7, add_drop_deadline=$8
+		WHERE id=$1
+		RETURNING `+termColumns,
+		t.ID, t.Code, t.Name, t.StartsOn, t.EndsOn, t.EnrollmentOpensAt,
+		t.EnrollmentClosesAt, t.AddDropDeadline))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return t, ErrTermNotFound
+	}
+	return t, classify(termError(err))
+}
+
+func (r *PgTermRepository) CreateOffering(ctx context.Context, o Offering, instructorEmails []string) (Offering, error) {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := lockTermSchedule(ctx, tx, o.TermID); err != nil {
+			return err
+		}
+		if err := courseExists(ctx, tx, o.CourseID); err != nil {
+			return err
+		}
+		if err := checkRoomClashes(ctx, tx, o.TermID, 0, o.Meetings); err != nil {
+			return err
+		}
+
+		var id int
+		err := tx.QueryRow(ctx, `
+			INSERT INTO course_offerings (term_id, course_id, section, capacity)
+			VALUES ($1, $2, $3, $4)
+			RETURNING id`,
+			o.TermID, o.CourseID, o.Section, o.Capacity,
+		).Scan(&id)
+		if err != nil {
+			return offeringError(err)
+		}
+		if err := setInstructors(ctx, tx, id, instructorEmails); err != nil {
+			return err
+		}
+		if err := setMeetings(ctx, tx, id, o.Meetings); err != nil {
+			return err
+		}
+
+		o, err = getOffering(ctx, tx, id)
+		return err
+	})
+	return o, classify(err)
+}
+
+func (r *PgTermRepository) GetOffering(ctx context.Context, id int) (Offering, error) {
+	o, err := getOffering(ctx, r.db, id)
+	return o, classify(err)
+}
+
+func getOffering(ctx context.Context, db dbtx, id int) (Offering, error) {
+	o, err := scanOffering(db.QueryRow(ctx, offeringSelect+` WHERE o.id=$1`, id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return o, ErrOfferingNotFound
+	}
+	if err != nil {
+		return o, err
+	}
+
+	offerings := []Offering{o}
+	if err := loadOfferingDetails(ctx, db, offerings); err != nil {
+		return o, err
+	}
+	return offerings[0], nil
+}
+
+func (r *PgTermRepository) ListOfferings(ctx context.Context, termID int) ([]Offering, error) {
+	if err := termExists(ctx, r.db, termID); err != nil {
+		return nil, classify(err)
+	}
+
+	rows, err := r.db.Query(ctx, offeringSelect+`
+		WHERE o.term_id=$1
+		ORDER BY c.code, o.section`, termID)
+	if err != nil {
+		return nil, classify(err)
+	}
+	offerings, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (Offering, error) {
+		return scanOffering(row)
+	})
+	if err != nil {
+		return nil, classify(err)
+	}
+	return offerings, classify(loadOfferingDetails(ctx, r.db, offerings))
+}
+
+func (r *PgTermRepository) UpdateOffering(ctx context.Context, o Offering, instructorEmails []string) (Offering, error) {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		var termID int
+		err := tx.QueryRow(ctx, `SELECT term_id FROM course_offerings WHERE id=$1`, o.ID).Scan(&termID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrOfferingNotFound
+		}
+		if err != nil {
+			return err
+		}
+		if err := lockTermSchedule(ctx, tx, termID); err != nil {
+			return err
+		}
+		if err := checkRoomClashes(ctx, tx, termID, o.ID, o.Meetings); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `
+			UPDATE course_offerings
+			SET section=$2, capacity=$3, updated_at=NOW()
+			WHERE id=$1`,
+			o.ID, o.Section, o.Capacity)
+		if err != nil {
+			return offeringError(err)
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrOfferingNotFound
+		}
+		if instructorEmails != nil {
+			if err := setInstructors(ctx, tx, o.ID, instructorEmails); err != nil {
+				return err
+			}
+		}
+		if err := setMeetings(ctx, tx, o.ID, o.Meetings); err != nil {
+			return err
+		}
+
+		// The update holds the offering's row lock, so added seats can go
+		// to the waitlist straight away.
+		if _, err := promoteWaitlist(ctx, tx, o.ID); err != nil {
+			return err
+		}
+
+		o, err = getOffering(ctx, tx, o.ID)
+		return err
+	})
+	return o, classify(err)
+}
+
+func (r *PgTermRepository) DeleteOffering(ctx context.Context, id int) error {
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		// The offering lock keeps students from enrolling while we check.
+		if _, err := lockOffering(ctx, tx, id); err != nil {
+			return err
+		}
+		var live bool
+		err := tx.QueryRow(ctx, `
+			SELECT EXISTS (SELECT 1 FROM enrollments WHERE offering_id=$1 AND status <> 'dropped')`,
+			id).Scan(&live)
+		if err != nil {
+			return err
+		}
+		if live {
+			return ErrOfferingHasEnrollments
+		}
+		_, err = tx.Exec(ctx, `DELETE FROM course_offerings WHERE id=$1`, id)
+		return err
+	})
+	return classify(err)
+}
+
+func (r *PgTermRepository) CloneOfferings(ctx context.Context, fromTermID, toTermID int) (CloneResult, error) {
+	result := CloneResult{Clashes: []CloneClash{}}
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := termExists(ctx, tx, fromTermID); err != nil {
+			return err
+		}
+		if err := lockTermSchedule(ctx, tx, toTermID); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, `
+			SELECT o.id, o.course_id, c.code, o.section, o.capacity
+			FROM course_offerings o JOIN courses c ON c.id = o.course_id
+			WHERE o.term_id=$1
+			ORDER BY o.id`, fromTermID)
+		if err != nil {
+			return err
+		}
+		type source struct {
+			id, courseID, capacity int
+			courseCode, section    string
+		}
+		sources, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (source, error) {
+			var s source
+			err := row.Scan(&s.id, &s.courseID, &s.courseCode, &s.section, &s.capacity)
+			return s, err
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, s := range sources {
+			var exists bool
+			err := tx.QueryRow(ctx, `
+				SELECT EXISTS (
+					SELECT 1 FROM course_offerings
+					WHERE term_id=$1 AND course_id=$2 AND section=$3)`,
+				toTermID, s.courseID, s.section,
+			).Scan(&exists)
+			if err != nil {
+				return err
+			}
+			if exists {
+				result.Skipped++
+				continue
+			}
+
+			// Offerings cloned earlier in the loop count as bookings too.
+			scheduled, err := scheduledMeetings(ctx, tx, `o.id = $1`, s.id)
+			if err != nil {
+				return err
+			}
+			meetings := make([]Meeting, len(scheduled))
+			for i, m := range scheduled {
+				meetings[i] = m.Meeting
+			}
+			err = checkRoomClashes(ctx, tx, toTermID, 0, meetings)
+			var clash *ClashError
+			if errors.As(err, &clash) {
+				result.Clashes = append(result.Clashes, CloneClash{
+					OfferingID: s.id,
+					CourseCode: s.courseCode,
+					Section:    s.section,
+					Meeting:    clash.Clash.Meeting,
+					With:       clash.Clash.With,
+				})
+				continue
+			}
+			if err != nil {
+				return err
+			}
+
+			var id int
+			err = tx.QueryRow(ctx, `
+				INSERT INTO course_offerings (term_id, course_id, section, capacity)
+				VALUES ($1, $2, $3, $4)
+				RETURNING id`,
+				toTermID, s.courseID, s.section, s.capacity,
+			).Scan(&id)
+			if err != nil {
+				return offeringError(err)
+			}
+
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO offering_instructors (offering_id, staff_id)
+				SELECT $1, staff_id FROM offering_instructors WHERE offering_id=$2`,
+				id, s.id); err != nil {
+				return err
+			}
+			if _, err := tx.Exec(ctx, `
+				INSERT INTO offering_meetings (offering_id, weekday, starts_at, ends_at, room)
+				SELECT $1, weekday, starts_at, ends_at, room FROM offering_meetings WHERE offering_id=$2`,
+				id, s.id); err != nil {
+				return err
+			}
+			result.Created++
+		}
+		return nil
+	})
+	return result, classify(err)
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"elimu-go/internal/testdb"
+)
+
+func newTermRepo(t *testing.T) (*PgTermRepository, int) {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO staff (first_name, last_name, email, role) VALUES
+			('Ruth', 'Njeri', 'ruth@school.edu', 'teacher');
+		INSERT INTO courses (code, title, credits, department) VALUES
+			('CS101', 'Intro to Programming', 3, 'CS')`)
+	return NewPgTermRepository(pool), courseIDByCode(t, pool, "CS101")
+}
+
+func TestPgTermRepository_OfferingsAndClone(t *testing.T) {
+	terms, courseID := newTermRepo(t)
+	ctx := context.Background()
+
+	first := createTerm(t, terms, "2026-S1", time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC))
+	second := createTerm(t, terms, "2026-S2", time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC))
+
+	if _, err := terms.CreateTerm(ctx, first); !errors.Is(err, ErrTermCodeTaken) {
+		t.Errorf("Expected ErrTermCodeTaken for a duplicate code, got %v", err)
+	}
+
+	offering, err := terms.CreateOffering(ctx, Offering{
+		TermID:   first.ID,
+		CourseID: courseID,
+		Section:  "A",
+		Capacity: 40,
+		Meetings: []Meeting{
+			{Weekday: 3, StartsAt: "14:00", EndsAt: "15:30", Room: "LT2"},
+			{Weekday: 1, StartsAt: "09:00", EndsAt: "10:30", Room: "LT1"},
+		},
+	}, []string{"ruth@school.edu"})
+	if err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+	if offering.CourseCode != "CS101" || len(offering.Instructors) != 1 || len(offering.Meetings) != 2 {
+		t.Fatalf("Expected CS101 with one instructor and two meetings, got %+v", offering)
+	}
+	if m := offering.Meetings[0]; m.Weekday != 1 || m.StartsAt != "09:00" || m.EndsAt != "10:30" {
+		t.Errorf("Expected meetings ordered by weekday as HH:MM, got %+v", offering.Meetings)
+	}
+
+	if _, err := terms.CreateOffering(ctx, Offering{TermID: first.ID, CourseID: courseID, Section: "A"}, nil); !errors.Is(err, ErrSectionTaken) {
+		t.Errorf("Expected ErrSectionTaken for a duplicate section, got %v", err)
+	}
+
+	result, err := terms.CloneOfferings(ctx, first.ID, second.ID)
+	if err != nil {
+		t.Fatalf("CloneOfferings: %v", err)
+	}
+	if result.Created != 1 || result.Skipped != 0 {
+		t.Errorf("Expected one offering cloned, got %+v", result)
+	}
+
+	cloned, err := terms.ListOfferings(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("ListOfferings: %v", err)
+	}
+	if len(cloned) != 1 || len(cloned[0].Meetings) != 2 || len(cloned[0].Instructors) != 1 {
+		t.Errorf("Expected the clone to keep meetings and instructors, got %+v", cloned)
+	}
+
+	result, err = terms.CloneOfferings(ctx, first.ID, second.ID)
+	if err != nil {
+		t.Fatalf("CloneOfferings again: %v", err)
+	}
+	if result.Created != 0 || result.Skipped != 1 {
+		t.Errorf("Expected cloning twice to skip existing sections, got %+v", result)
+	}
+}
+
+func TestPgTermRepository_CloneSkipsBookedRooms(t *testing.T) {
+	terms, courseID := newTermRepo(t)
+	ctx := context.Background()
+
+	first := createTerm(t, terms, "2026-S1", time.Date(2026, 1, 12, 0, 0, 0, 0, time.UTC))
+	second := createTerm(t, terms, "2026-S2", time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC))
+
+	lecture := Meeting{Weekday: 1, StartsAt: "09:00", EndsAt: "10:30", Room: "LT1"}
+	source, err := terms.CreateOffering(ctx, Offering{
+		TermID: first.ID, CourseID: courseID, Section: "A", Capacity: 40, Meetings: []Meeting{lecture},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+	booked, err := terms.CreateOffering(ctx, Offering{
+		TermID: second.ID, CourseID: courseID, Section: "B", Capacity: 40,
+		Meetings: []Meeting{{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00", Room: "LT1"}},
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+
+	result, err := terms.CloneOfferings(ctx, first.ID, second.ID)
+	if err != nil {
+		t.Fatalf("CloneOfferings: %v", err)
+	}
+	if result.Created != 0 || len(result.Clashes) != 1 {
+		t.Fatalf("Expected the clashing offering to be left out, got %+v", result)
+	}
+	if c := result.Clashes[0]; c.OfferingID != source.ID || c.Meeting != lecture || c.With.OfferingID != booked.ID {
+		t.Errorf("Expected the clash to name both offerings, got %+v", c)
+	}
+
+	offerings, err := terms.ListOfferings(ctx, second.ID)
+	if err != nil {
+		t.Fatalf("ListOfferings: %v", err)
+	}
+	if len(offerings) != 1 {
+		t.Errorf("Expected only the booked offering in the target term, got %+v", offerings)
+	}
+}
+
+func TestPgTermRepository_DeleteOfferingKeepsEnrollments(t *testing.T) {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO students (first_name, last_name, email) VALUES
+			('Amani', 'Wanjiru', 'amani@student.school.edu');
+		INSERT INTO courses (code, title, credits, department) VALUES
+			('CS101', 'Intro to Programming', 3, 'CS')`)
+	terms := NewPgTermRepository(pool)
+	roster := NewPgRosterRepository(pool)
+	ctx := context.Background()
+
+	term := createTerm(t, terms, "2026-S2", time.Date(2026, 9, 7, 0, 0, 0, 0, time.UTC))
+	offering, err := terms.CreateOffering(ctx, Offering{
+		TermID:   term.ID,
+		CourseID: courseIDByCode(t, pool, "CS101"),
+		Section:  "A",
+		Capacity: 10,
+	}, nil)
+	if err != nil {
+		t.Fatalf("CreateOffering: %v", err)
+	}
+	enrollment, err := roster.AddStudent(ctx, offering.ID, "amani@student.school.edu", "ruth@school.edu", "")
+	if err != nil {
+		t.Fatalf("AddStudent: %v", err)
+	}
+
+	if err := terms.DeleteOffering(ctx, offering.ID); !errors.Is(err, ErrOfferingHasEnrollments) {
+		t.Fatalf("Expected ErrOfferingHasEnrollments with a student enrolled, got %v", err)
+	}
+	if _, err := roster.RemoveStudent(ctx, offering.ID, enrollment.ID, "ruth@school.edu", ""); err != nil {
+		t.Fatalf("RemoveStudent: %v", err)
+	}
+	if err := terms.DeleteOffering(ctx, offering.ID); err != nil {
+		t.Errorf("Expected the offering to delete once its roster is empty, got %v", err)
+	}
+	if err := terms.DeleteOffering(ctx, offering.ID); !errors.Is(err, ErrOfferingNotFound) {
+		t.Errorf("Expected ErrOfferingNotFound deleting twice, got %v", err)
+	}
+}
@@ -0,0 +1,14 @@
+package repository
+
+import "testing"
+
+func TestOffering_IsInstructor(t *testing.T) {
+	o := Offering{Instructors: []Instructor{{Email: "ruth@school.edu"}}}
+
+	if !o.IsInstructor("RUTH@school.edu") {
+		t.Error("Expected instructors to match regardless of case")
+	}
+	if o.IsInstructor("grace@school.edu") {
+		t.Error("Expected other staff not to match")
+	}
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"time"
+)
+
+var (
+	ErrTimetableClash = fmt.Errorf("%w: timetable clash", ErrConflict)
+	ErrRoomBooked     = fmt.Errorf("%w: room is already booked", ErrConflict)
+)
+
+// Overlaps reports whether two weekly meetings share any time. Meetings
+// that only touch, one ending as the other starts, do not overlap.
+func (m Meeting) Overlaps(other Meeting) bool {
+	// "HH:MM" strings order the same way as the times they hold.
+	return m.Weekday == other.Weekday && m.StartsAt < other.EndsAt && other.StartsAt < m.EndsAt
+}
+
+func (m Meeting) String() string {
+	return fmt.Sprintf("%s %s-%s", time.Weekday(m.Weekday%7), m.StartsAt, m.EndsAt)
+}
+
+// ScheduledMeeting is a meeting of a particular offering.
+type ScheduledMeeting struct {
+	OfferingID int    `json:"offering_id"`
+	CourseCode string `json:"course_code"`
+	Section    string `json:"section"`
+	Meeting
+}
+
+// Clash is a meeting that overlaps a meeting of another offering.
+type Clash struct {
+	Meeting Meeting
+	With    ScheduledMeeting
+}
+
+// ClashError reports the first clash that stopped a change. It matches
+// ErrTimetableClash or ErrRoomBooked with errors.Is.
+type ClashError struct {
+	Kind  error
+	Clash Clash
+}
+
+func (e *ClashError) Error() string {
+	with := e.Clash.With
+	if e.Kind == ErrRoomBooked {
+		return fmt.Sprintf("%v: %s is used by %s section %s on %s", e.Kind, with.Room, with.CourseCode, with.Section, with.Meeting)
+	}
+	return fmt.Sprintf("%v: %s section %s meets %s", e.Kind, with.CourseCode, with.Section, with.Meeting)
+}
+
+func (e *ClashError) Unwrap() error {
+	return e.Kind
+}
+
+// findClashes returns every overlap between meetings and existing, in the
+// order of meetings. With sameRoom set only overlaps in the same room
+// count, and meetings without a room never clash.
+func findClashes(meetings []Meeting, existing []ScheduledMeeting, sameRoom bool) []Clash {
+	byStart := slices.Clone(existing)
+	slices.SortFunc(byStart, func(a, b ScheduledMeeting) int {
+		return cmp.Or(cmp.Compare(a.Weekday, b.Weekday), cmp.Compare(a.StartsAt, b.StartsAt))
+	})
+
+	var clashes []Clash
+	for _, m := range meetings {
+		if sameRoom && m.Room == "" {
+			continue
+		}
+		// Skip to the first meeting that could overlap m, then stop at the
+		// first one starting after m ends.
+		i, _ := slices.BinarySearchFunc(byStart, m.Weekday, func(e ScheduledMeeting, weekday int) int {
+			return cmp.Compare(e.Weekday, weekday)
+		})
+		for _, e := range byStart[i:] {
+			if e.Weekday != m.Weekday || e.StartsAt >= m.EndsAt {
+				break
+			}
+			if !m.Overlaps(e.Meeting) || (sameRoom && e.Room != m.Room) {
+				continue
+			}
+			clashes = append(clashes, Clash{Meeting: m, With: e})
+		}
+	}
+	return clashes
+}
+
+// checkClashes returns a ClashError of kind for the first clash found.
+func checkClashes(kind error, meetings []Meeting, existing []ScheduledMeeting) error {
+	clashes := findClashes(meetings, existing, kind == ErrRoomBooked)
+	if len(clashes) == 0 {
+		return nil
+	}
+	return &ClashError{Kind: kind, Clash: clashes[0]}
+}
+
+// TimetableEntry is a weekly meeting on someone's timetable.
+type TimetableEntry struct {
+	ScheduledMeeting
+	CourseTitle string `json:"course_title"`
+
+	// Role is "student" for offerings taken and "instructor" for those
+	// taught.
+	Role string `json:"role"`
+
+	// Status is the enrollment status for offerings taken.
+	Status string `json:"status,omitempty"`
+}
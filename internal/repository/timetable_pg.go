@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+)
+
+const scheduledMeetingSelect = `
+	SELECT o.id, c.code, o.section, m.weekday, to_char(m.starts_at, 'HH24:MI'),
+		to_char(m.ends_at, 'HH24:MI'), m.room
+	FROM offering_meetings m
+	JOIN course_offerings o ON o.id = m.offering_id
+	JOIN courses c ON c.id = o.course_id`
+
+func scheduledMeetings(ctx context.Context, db dbtx, where string, args ...any) ([]ScheduledMeeting, error) {
+	rows, err := db.Query(ctx, scheduledMeetingSelect+" WHERE "+where, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (ScheduledMeeting, error) {
+		var s ScheduledMeeting
+		err := row.Scan(&s.OfferingID, &s.CourseCode, &s.Section, &s.Weekday, &s.StartsAt, &s.EndsAt, &s.Room)
+		return s, err
+	})
+}
+
+// checkStudentClashes returns a ClashError if the offering meets while
+// another offering the student is enrolled or waitlisted in that term
+// does. The caller must hold the student's row lock.
+func checkStudentClashes(ctx context.Context, db dbtx, offeringID, studentID int) error {
+	target, err := scheduledMeetings(ctx, db, `o.id = $1`, offeringID)
+	if err != nil || len(target) == 0 {
+		return err
+	}
+	taken, err := scheduledMeetings(ctx, db, `
+		o.id <> $1
+		AND o.term_id = (SELECT term_id FROM course_offerings WHERE id = $1)
+		AND o.id IN (SELECT offering_id FROM enrollments WHERE student_id = $2 AND status <> 'dropped')`,
+		offeringID, studentID)
+	if err != nil {
+		return err
+	}
+
+	meetings := make([]Meeting, len(target))
+	for i, s := range target {
+		meetings[i] = s.Meeting
+	}
+	return checkClashes(ErrTimetableClash, meetings, taken)
+}
+
+// checkRoomClashes returns a ClashError if any of the meetings would use a
+// room another offering of the term has booked at the same time. The
+// caller must hold the term's schedule lock.
+func checkRoomClashes(ctx context.Context, db dbtx, termID, offeringID int, meetings []Meeting) error {
+	rooms := make([]string, 0, len(meetings))
+	for _, m := range meetings {
+		if m.Room != "" {
+			rooms = append(rooms, m.Room)
+		}
+	}
+	if len(rooms) == 0 {
+		return nil
+	}
+	booked, err := scheduledMeetings(ctx, db, `o.term_id = $1 AND o.id <> $2 AND m.room = ANY($3)`,
+		termID, offeringID, rooms)
+	if err != nil {
+		return err
+	}
+	return checkClashes(ErrRoomBooked, meetings, booked)
+}
+
+// lockTermSchedule serialises changes to the meetings of a term's
+// offerings, so two offerings cannot book a room at once.
+func lockTermSchedule(ctx context.Context, tx pgx.Tx, termID int) error {
+	var id int
+	err := tx.QueryRow(ctx, `SELECT id FROM terms WHERE id=$1 FOR UPDATE`, termID).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return ErrTermNotFound
+	}
+	return err
+}
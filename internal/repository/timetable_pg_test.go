@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgTimetable_Clashes(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	testdb.Exec(t, pool, `
+		INSERT INTO staff (first_name, last_name, email, role) VALUES
+			('Ruth', 'Njeri', 'ruth@school.edu', 'teacher');
+		INSERT INTO courses (code, title, credits, department) VALUES
+			('MA101', 'Calculus I', 3, 'MA'),
+			('PH101', 'Mechanics', 3, 'PH')`)
+	terms := NewPgTermRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	ctx := context.Background()
+
+	term := createTerm(t, terms, "NOW", time.Now().AddDate(0, 0, 7))
+	schedule := func(code string, m Meeting) (Offering, error) {
+		return terms.CreateOffering(ctx, Offering{
+			TermID:   term.ID,
+			CourseID: courseIDByCode(t, pool, code),
+			Section:  "A",
+			Capacity: 30,
+			Meetings: []Meeting{m},
+		}, []string{"ruth@school.edu"})
+	}
+
+	cs, err := schedule("CS101", Meeting{Weekday: 1, StartsAt: "09:00", EndsAt: "10:30", Room: "LT1"})
+	if err != nil {
+		t.Fatalf("CreateOffering CS101: %v", err)
+	}
+	_, err = schedule("MA101", Meeting{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00", Room: "LT1"})
+	var clash *ClashError
+	if !errors.As(err, &clash) || !errors.Is(err, ErrRoomBooked) || clash.Clash.With.OfferingID != cs.ID {
+		t.Fatalf("Expected LT1 to be booked by CS101, got %v", err)
+	}
+	ma, err := schedule("MA101", Meeting{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00", Room: "LT2"})
+	if err != nil {
+		t.Fatalf("CreateOffering MA101: %v", err)
+	}
+	ph, err := schedule("PH101", Meeting{Weekday: 1, StartsAt: "10:30", EndsAt: "12:00", Room: "LT1"})
+	if err != nil {
+		t.Fatalf("Expected back-to-back bookings of LT1 to be allowed, got %v", err)
+	}
+
+	// Moving MA101 into LT1 is checked the same way.
+	ma.Meetings[0].Room = "LT1"
+	if _, err := terms.UpdateOffering(ctx, ma, nil); !errors.Is(err, ErrRoomBooked) {
+		t.Errorf("Expected ErrRoomBooked moving MA101 into LT1, got %v", err)
+	}
+
+	if _, err := enrollments.Enroll(ctx, cs.ID, "amani@student.school.edu", false); err != nil {
+		t.Fatalf("Enroll CS101: %v", err)
+	}
+	_, err = enrollments.Enroll(ctx, ma.ID, "amani@student.school.edu", false)
+	if !errors.As(err, &clash) || !errors.Is(err, ErrTimetableClash) || clash.Clash.With.CourseCode != "CS101" {
+		t.Errorf("Expected MA101 to clash with CS101, got %v", err)
+	}
+	if _, err := enrollments.Enroll(ctx, ph.ID, "amani@student.school.edu", false); err != nil {
+		t.Fatalf("Enroll PH101: %v", err)
+	}
+
+	timetable, err := enrollments.Timetable(ctx, "amani@student.school.edu", term.ID)
+	if err != nil {
+		t.Fatalf("Timetable: %v", err)
+	}
+	if len(timetable) != 2 || timetable[0].CourseCode != "CS101" || timetable[1].CourseCode != "PH101" || timetable[0].Role != "student" {
+		t.Errorf("Expected CS101 then PH101, got %+v", timetable)
+	}
+	teaching, err := enrollments.Timetable(ctx, "ruth@school.edu", term.ID)
+	if err != nil {
+		t.Fatalf("Timetable: %v", err)
+	}
+	if len(teaching) != 3 || teaching[0].Role != "instructor" {
+		t.Errorf("Expected Ruth to teach three meetings, got %+v", teaching)
+	}
+}
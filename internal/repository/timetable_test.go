@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMeeting_Overlaps(t *testing.T) {
+	nine := Meeting{Weekday: 1, StartsAt: "09:00", EndsAt: "10:30"}
+	cases := []struct {
+		other Meeting
+		want  bool
+	}{
+		{Meeting{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00"}, true},
+		{Meeting{Weekday: 1, StartsAt: "08:00", EndsAt: "12:00"}, true},
+		{Meeting{Weekday: 1, StartsAt: "10:30", EndsAt: "12:00"}, false},
+		{Meeting{Weekday: 1, StartsAt: "07:30", EndsAt: "09:00"}, false},
+		{Meeting{Weekday: 2, StartsAt: "09:00", EndsAt: "10:30"}, false},
+	}
+	for _, tc := range cases {
+		if got := nine.Overlaps(tc.other); got != tc.want {
+			t.Errorf("%v overlaps %v: expected %v", nine, tc.other, tc.want)
+		}
+		if got := tc.other.Overlaps(nine); got != tc.want {
+			t.Errorf("Overlaps is not symmetric for %v", tc.other)
+		}
+	}
+}
+
+func TestFindClashes(t *testing.T) {
+	existing := []ScheduledMeeting{
+		{OfferingID: 3, CourseCode: "MA101", Section: "A", Meeting: Meeting{Weekday: 3, StartsAt: "14:00", EndsAt: "15:00", Room: "LT1"}},
+		{OfferingID: 2, CourseCode: "CS201", Section: "B", Meeting: Meeting{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00", Room: "LT2"}},
+		{OfferingID: 4, CourseCode: "PH101", Section: "A", Meeting: Meeting{Weekday: 1, StartsAt: "08:00", EndsAt: "09:00", Room: "LT1"}},
+	}
+	meetings := []Meeting{
+		{Weekday: 1, StartsAt: "09:00", EndsAt: "10:30", Room: "LT1"},
+		{Weekday: 3, StartsAt: "13:00", EndsAt: "14:00", Room: "LT1"},
+	}
+
+	clashes := findClashes(meetings, existing, false)
+	if len(clashes) != 1 || clashes[0].With.CourseCode != "CS201" {
+		t.Errorf("Expected only CS201 to clash, got %+v", clashes)
+	}
+	if clashes := findClashes(meetings, existing, true); len(clashes) != 0 {
+		t.Errorf("Expected no room clash in different rooms, got %+v", clashes)
+	}
+
+	meetings[0].Room = "LT2"
+	err := checkClashes(ErrRoomBooked, meetings, existing)
+	if !errors.Is(err, ErrRoomBooked) || !errors.Is(err, ErrConflict) {
+		t.Fatalf("Expected ErrRoomBooked, got %v", err)
+	}
+	if want := "conflict: room is already booked: LT2 is used by CS201 section B on Monday 10:00-11:00"; err.Error() != want {
+		t.Errorf("Expected %q, got %q", want, err.Error())
+	}
+
+	// Meetings without a room never double-book one.
+	if err := checkClashes(ErrRoomBooked, []Meeting{{Weekday: 1, StartsAt: "10:00", EndsAt: "11:00"}}, existing); err != nil {
+		t.Errorf("Expected no clash without a room, got %v", err)
+	}
+}
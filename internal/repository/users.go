@@ -0,0 +1,53 @@
+package repository
+
+import (
+	"context"
+	"time"
+)
+
+// StudentRow is a pre-registered student.
+type StudentRow struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+}
+
+// StaffRow is a pre-registered staff member.
+type StaffRow struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email"`
+	Role      string `json:"role"`
+}
+
+// UserExportRow is a single student or staff member in an export.
+type UserExportRow struct {
+	Type      string
+	FirstName string
+	LastName  string
+	Email     string
+	Role      string
+	CreatedAt *time.Time
+}
+
+// UserExportFilter narrows the rows returned by an export.
+type UserExportFilter struct {
+	Type          string
+	Role          string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+}
+
+// UserRepository looks up pre-registered students and staff.
+type UserRepository interface {
+	// FindRole returns the role of a registered user, or ErrNotFound if
+	// email is not pre-registered. Students always have the role "student".
+	FindRole(ctx context.Context, email string) (string, error)
+
+	ListStudents(ctx context.Context) ([]StudentRow, error)
+	ListStaff(ctx context.Context) ([]StaffRow, error)
+
+	// StreamUsers calls fn for each matching user in type, email order
+	// without loading them all into memory.
+	StreamUsers(ctx context.Context, f UserExportFilter, fn func(UserExportRow) error) error
+}
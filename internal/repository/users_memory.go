@@ -0,0 +1,185 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryUserRepository is an in-memory UserRepository for tests.
+type MemoryUserRepository struct {
+	mu       sync.RWMutex
+	students []StudentRow
+	staff    []StaffRow
+
+	// created holds when each user was added, by lower-cased email.
+	created map[string]time.Time
+
+	// Err, when set, is returned by every method.
+	Err error
+}
+
+func NewMemoryUserRepository() *MemoryUserRepository {
+	return &MemoryUserRepository{created: make(map[string]time.Time)}
+}
+
+func (r *MemoryUserRepository) AddStudent(s StudentRow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.students = append(r.students, s)
+	r.created[strings.ToLower(s.Email)] = time.Now()
+}
+
+func (r *MemoryUserRepository) AddStaff(s StaffRow) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.staff = append(r.staff, s)
+	r.created[strings.ToLower(s.Email)] = time.Now()
+}
+
+// SetCreatedAt backdates when a user was added, for export filters.
+func (r *MemoryUserRepository) SetCreatedAt(email string, at time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.created[strings.ToLower(email)] = at
+}
+
+// staffRole returns the role of the staff member with email.
+func (r *MemoryUserRepository) staffRole(email string) (string, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, s := range r.staff {
+		if strings.EqualFold(s.Email, email) {
+			return s.Role, true
+		}
+	}
+	return "", false
+}
+
+// staffMember returns the staff member with email and an ID numbering
+// staff in the order they were added.
+func (r *MemoryUserRepository) staffMember(email string) (StaffRow, int, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for i, s := range r.staff {
+		if strings.EqualFold(s.Email, email) {
+			return s, i + 1, true
+		}
+	}
+	return StaffRow{}, 0, false
+}
+
+// isStudent reports whether a student has exactly email, as
+// studentIDByEmail matches it.
+func (r *MemoryUserRepository) isStudent(email string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return slices.ContainsFunc(r.students, func(s StudentRow) bool { return s.Email == email })
+}
+
+// SetStaffRole changes the role of an existing staff member.
+func (r *MemoryUserRepository) SetStaffRole(email, role string) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i := range r.staff {
+		if strings.EqualFold(r.staff[i].Email, email) {
+			r.staff[i].Role = role
+			return true
+		}
+	}
+	return false
+}
+
+func (r *MemoryUserRepository) FindRole(_ context.Context, email string) (string, error) {
+	if r.Err != nil {
+		return "", r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	for _, s := range r.students {
+		if s.Email == email {
+			return "student", nil
+		}
+	}
+	for _, s := range r.staff {
+		if s.Email == email {
+			return s.Role, nil
+		}
+	}
+	return "", ErrNotFound
+}
+
+func (r *MemoryUserRepository) ListStudents(context.Context) ([]StudentRow, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]StudentRow(nil), r.students...), nil
+}
+
+func (r *MemoryUserRepository) ListStaff(context.Context) ([]StaffRow, error) {
+	if r.Err != nil {
+		return nil, r.Err
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return append([]StaffRow(nil), r.staff...), nil
+}
+
+func (r *MemoryUserRepository) StreamUsers(_ context.Context, f UserExportFilter, fn func(UserExportRow) error) error {
+	if r.Err != nil {
+		return r.Err
+	}
+
+	r.mu.RLock()
+	createdAt := func(email string) *time.Time {
+		if at, ok := r.created[strings.ToLower(email)]; ok {
+			return &at
+		}
+		return nil
+	}
+	var all []UserExportRow
+	for _, s := range r.students {
+		all = append(all, UserExportRow{Type: "student", FirstName: s.FirstName, LastName: s.LastName, Email: s.Email, Role: "student", CreatedAt: createdAt(s.Email)})
+	}
+	for _, s := range r.staff {
+		all = append(all, UserExportRow{Type: "staff", FirstName: s.FirstName, LastName: s.LastName, Email: s.Email, Role: s.Role, CreatedAt: createdAt(s.Email)})
+	}
+	r.mu.RUnlock()
+
+	sort.Slice(all, func(i, j int) bool {
+		if all[i].Type != all[j].Type {
+			return all[i].Type < all[j].Type
+		}
+		return all[i].Email < all[j].Email
+	})
+
+	for _, u := range all {
+		if f.Type != "" && u.Type != f.Type {
+			continue
+		}
+		if f.Role != "" && u.Role != f.Role {
+			continue
+		}
+		// As in SQL, users without a creation time never match a date
+		// filter.
+		if f.CreatedAfter != nil && (u.CreatedAt == nil || u.CreatedAt.Before(*f.CreatedAfter)) {
+			continue
+		}
+		if f.CreatedBefore != nil && (u.CreatedAt == nil || !u.CreatedAt.Before(*f.CreatedBefore)) {
+			continue
+		}
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PgUserRepository reads users from the students and staff tables.
+type PgUserRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgUserRepository(db *pgxpool.Pool) *PgUserRepository {
+	return &PgUserRepository{db: db}
+}
+
+func (r *PgUserRepository) FindRole(ctx context.Context, email string) (string, error) {
+	var role string
+
+	// Staff without a role are still registered, so NULL becomes "".
+	err := r.db.QueryRow(ctx, `
+		SELECT 'student' FROM students WHERE email=$1
+		UNION ALL
+		SELECT COALESCE(role, '') FROM staff WHERE email=$1
+		LIMIT 1`,
+		email,
+	).Scan(&role)
+	if err != nil {
+		return "", classify(err)
+	}
+
+	return role, nil
+}
+
+func (r *PgUserRepository) ListStudents(ctx context.Context) ([]StudentRow, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT first_name, last_name, email FROM students ORDER BY email`)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	students, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (StudentRow, error) {
+		var s StudentRow
+		err := row.Scan(&s.FirstName, &s.LastName, &s.Email)
+		return s, err
+	})
+	return students, classify(err)
+}
+
+func (r *PgUserRepository) ListStaff(ctx context.Context) ([]StaffRow, error) {
+	rows, err := r.db.Query(ctx,
+		`SELECT first_name, last_name, email, COALESCE(role, '') FROM staff ORDER BY email`)
+	if err != nil {
+		return nil, classify(err)
+	}
+
+	staff, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (StaffRow, error) {
+		var s StaffRow
+		err := row.Scan(&s.FirstName, &s.LastName, &s.Email, &s.Role)
+		return s, err
+	})
+	return staff, classify(err)
+}
+
+func (r *PgUserRepository) StreamUsers(ctx context.Context, f UserExportFilter, fn func(UserExportRow) error) error {
+	rows, err := r.db.Query(ctx, `
+		SELECT type, first_name, last_name, email, role, created_at FROM (
+			SELECT 'student' AS type, first_name, last_name, email,
+			       'student' AS role, created_at
+			FROM students
+			UNION ALL
+			SELECT 'staff', first_name, last_name, email,
+			       COALESCE(role, ''), created_at
+			FROM staff
+		) u
+		WHERE ($1 = '' OR type = $1)
+		  AND ($2 = '' OR role = $2)
+		  AND ($3::timestamptz IS NULL OR created_at >= $3)
+		  AND ($4::timestamptz IS NULL OR created_at < $4)
+		ORDER BY type, email`,
+		f.Type, f.Role, f.CreatedAfter, f.CreatedBefore)
+	if err != nil {
+		return classify(err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var u UserExportRow
+		if err := rows.Scan(&u.Type, &u.FirstName, &u.LastName, &u.Email, &u.Role, &u.CreatedAt); err != nil {
+			return classify(err)
+		}
+		if err := fn(u); err != nil {
+			return err
+		}
+	}
+
+	return classify(rows.Err())
+}
@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"elimu-go/internal/testdb"
+)
+
+func seedUsers(t *testing.T) *PgUserRepository {
+	pool := testdb.New(t)
+	testdb.Exec(t, pool, `
+		INSERT INTO students (first_name, last_name, email) VALUES
+			('Amani', 'Wanjiru', 'amani@student.school.edu'),
+			('Baraka', 'Otieno', 'baraka@student.school.edu')`)
+	testdb.Exec(t, pool, `
+		INSERT INTO staff (first_name, last_name, email, role) VALUES
+			('Grace', 'Mwangi', 'grace@school.edu', 'admin'),
+			('Ruth', 'Njeri', 'ruth@school.edu', NULL)`)
+	return NewPgUserRepository(pool)
+}
+
+func TestPgUserRepository_FindRole(t *testing.T) {
+	r := seedUsers(t)
+	ctx := context.Background()
+
+	cases := map[string]string{
+		"amani@student.school.edu": "student",
+		"grace@school.edu":         "admin",
+		"ruth@school.edu":          "",
+	}
+	for email, want := range cases {
+		role, err := r.FindRole(ctx, email)
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", email, err)
+		}
+		if role != want {
+			t.Errorf("%s: expected role %q, got %q", email, want, role)
+		}
+	}
+
+	if _, err := r.FindRole(ctx, "stranger@gmail.com"); !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for unregistered email, got %v", err)
+	}
+}
+
+func TestPgUserRepository_List(t *testing.T) {
+	r := seedUsers(t)
+	ctx := context.Background()
+
+	students, err := r.ListStudents(ctx)
+	if err != nil || len(students) != 2 {
+		t.Errorf("Expected 2 students, got %d (%v)", len(students), err)
+	}
+
+	staff, err := r.ListStaff(ctx)
+	if err != nil || len(staff) != 2 {
+		t.Fatalf("Expected 2 staff, got %d (%v)", len(staff), err)
+	}
+	if staff[1].Email != "ruth@school.edu" || staff[1].Role != "" {
+		t.Errorf("Staff without a role should list with an empty role, got %+v", staff[1])
+	}
+}
+
+func TestPgUserRepository_StreamUsers(t *testing.T) {
+	r := seedUsers(t)
+
+	var emails []string
+	err := r.StreamUsers(context.Background(), UserExportFilter{Type: "staff", Role: "admin"},
+		func(u UserExportRow) error {
+			emails = append(emails, u.Email)
+			return nil
+		})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(emails) != 1 || emails[0] != "grace@school.edu" {
+		t.Errorf("Expected only grace@school.edu, got %v", emails)
+	}
+
+	stop := errors.New("stop")
+	err = r.StreamUsers(context.Background(), UserExportFilter{}, func(UserExportRow) error {
+		return stop
+	})
+	if !errors.Is(err, stop) {
+		t.Errorf("Callback errors should stop the stream, got %v", err)
+	}
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+var (
+	ErrEnrollmentNotOpen = fmt.Errorf("%w: enrollment has not opened", ErrConflict)
+	ErrEnrollmentClosed  = fmt.Errorf("%w: enrollment has closed", ErrConflict)
+	ErrOverrideNotFound  = fmt.Errorf("enrollment override %w", ErrNotFound)
+)
+
+// EnrollmentWindow is when a cohort of students may enroll in a term.
+// Students without a cohort window use the term's own window.
+type EnrollmentWindow struct {
+	Cohort   string    `json:"cohort"`
+	OpensAt  time.Time `json:"opens_at"`
+	ClosesAt time.Time `json:"closes_at"`
+}
+
+// Covers reports whether now falls inside the window.
+func (w EnrollmentWindow) Covers(now time.Time) bool {
+	return !now.Before(w.OpensAt) && now.Before(w.ClosesAt)
+}
+
+// StudentCohort is the cohort whose enrollment window a student uses.
+type StudentCohort struct {
+	StudentEmail string `json:"student_email"`
+
+	// Cohort is nil for a student who uses the term's own window.
+	Cohort *string `json:"cohort"`
+}
+
+// EnrollmentOverride lets one student enroll in a term outside their
+// window.
+type EnrollmentOverride struct {
+	ID           int    `json:"id"`
+	TermID       int    `json:"term_id"`
+	StudentEmail string `json:"student_email"`
+	Reason       string `json:"reason"`
+	GrantedBy    string `json:"granted_by"`
+
+	// ExpiresAt is nil for an override that lasts until revoked.
+	ExpiresAt *time.Time `json:"expires_at"`
+	RevokedBy *string    `json:"revoked_by"`
+	RevokedAt *time.Time `json:"revoked_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// WindowRepository stores per-cohort enrollment windows and the overrides
+// that let students enroll outside them.
+type WindowRepository interface {
+	// EnrollmentWindows returns the term's cohort windows ordered by
+	// opening time.
+	EnrollmentWindows(ctx context.Context, termID int) ([]EnrollmentWindow, error)
+
+	// SetEnrollmentWindows replaces the term's cohort windows.
+	SetEnrollmentWindows(ctx context.Context, termID int, windows []EnrollmentWindow) ([]EnrollmentWindow, error)
+
+	// GrantOverride records an override for the student, replacing any
+	// override they already hold for the term.
+	GrantOverride(ctx context.Context, o EnrollmentOverride) (EnrollmentOverride, error)
+
+	// ListOverrides returns the term's overrides, revoked ones included,
+	// newest first.
+	ListOverrides(ctx context.Context, termID int) ([]EnrollmentOverride, error)
+
+	// RevokeOverride ends a live override, recording who revoked it.
+	RevokeOverride(ctx context.Context, termID, id int, revokedBy string) (EnrollmentOverride, error)
+
+	// SetStudentCohort assigns the student to a cohort, or removes them
+	// from theirs when cohort is nil.
+	SetStudentCohort(ctx context.Context, email string, cohort *string) (StudentCohort, error)
+}
@@ -0,0 +1,212 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const overrideSelect = `
+	SELECT ov.id, ov.term_id, s.email, ov.reason, ov.granted_by, ov.expires_at,
+		ov.revoked_by, ov.revoked_at, ov.created_at
+	FROM enrollment_overrides ov JOIN students s ON s.id = ov.student_id`
+
+func scanOverride(row pgx.Row) (EnrollmentOverride, error) {
+	var o EnrollmentOverride
+	err := row.Scan(&o.ID, &o.TermID, &o.StudentEmail, &o.Reason, &o.GrantedBy, &o.ExpiresAt,
+		&o.RevokedBy, &o.RevokedAt, &o.CreatedAt)
+	return o, err
+}
+
+// checkEnrollmentWindow returns ErrEnrollmentNotOpen or ErrEnrollmentClosed,
+// saying when the student's window is, unless the student may enroll in
+// the offering's term right now. A live override allows enrollment at any
+// time.
+func checkEnrollmentWindow(ctx context.Context, db dbtx, offeringID, studentID int) error {
+	var (
+		w          EnrollmentWindow
+		cohort     *string
+		now        time.Time
+		overridden bool
+	)
+	err := db.QueryRow(ctx, `
+		SELECT s.cohort, COALESCE(w.opens_at, t.enrollment_opens_at),
+			COALESCE(w.closes_at, t.enrollment_closes_at), NOW(),
+			EXISTS (
+				SELECT 1 FROM enrollment_overrides ov
+				WHERE ov.term_id = t.id AND ov.student_id = s.id AND ov.revoked_at IS NULL
+					AND (ov.expires_at IS NULL OR ov.expires_at > NOW()))
+		FROM course_offerings o
+		JOIN terms t ON t.id = o.term_id
+		JOIN students s ON s.id = $2
+		LEFT JOIN enrollment_windows w ON w.term_id = t.id AND w.cohort = s.cohort
+		WHERE o.id = $1`,
+		offeringID, studentID,
+	).Scan(&cohort, &w.OpensAt, &w.ClosesAt, &now, &overridden)
+	if err != nil {
+		return err
+	}
+	if overridden || w.Covers(now) {
+		return nil
+	}
+
+	who := "this term"
+	if cohort != nil {
+		who = "cohort " + *cohort
+	}
+	if now.Before(w.OpensAt) {
+		return fmt.Errorf("%w: enrollment for %s opens at %s", ErrEnrollmentNotOpen, who, w.OpensAt.UTC().Format(time.RFC3339))
+	}
+	return fmt.Errorf("%w: enrollment for %s closed at %s", ErrEnrollmentClosed, who, w.ClosesAt.UTC().Format(time.RFC3339))
+}
+
+// PgWindowRepository stores enrollment windows and overrides in Postgres.
+type PgWindowRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPgWindowRepository(db *pgxpool.Pool) *PgWindowRepository {
+	return &PgWindowRepository{db: db}
+}
+
+func enrollmentWindows(ctx context.Context, db dbtx, termID int) ([]EnrollmentWindow, error) {
+	rows, err := db.Query(ctx, `
+		SELECT cohort, opens_at, closes_at FROM enrollment_windows
+		WHERE term_id=$1
+		ORDER BY opens_at, cohort`, termID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (EnrollmentWindow, error) {
+		var w EnrollmentWindow
+		err := row.Scan(&w.Cohort, &w.OpensAt, &w.ClosesAt)
+		return w, err
+	})
+}
+
+func (r *PgWindowRepository) EnrollmentWindows(ctx context.Context, termID int) ([]EnrollmentWindow, error) {
+	if err := termExists(ctx, r.db, termID); err != nil {
+		return nil, classify(err)
+	}
+	windows, err := enrollmentWindows(ctx, r.db, termID)
+	return windows, classify(err)
+}
+
+func (r *PgWindowRepository) SetEnrollmentWindows(ctx context.Context, termID int, windows []EnrollmentWindow) ([]EnrollmentWindow, error) {
+	var saved []EnrollmentWindow
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := termExists(ctx, tx, termID); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `DELETE FROM enrollment_windows WHERE term_id=$1`, termID); err != nil {
+			return err
+		}
+
+		cohorts := make([]string, len(windows))
+		opens := make([]time.Time, len(windows))
+		closes := make([]time.Time, len(windows))
+		for i, w := range windows {
+			cohorts[i], opens[i], closes[i] = w.Cohort, w.OpensAt, w.ClosesAt
+		}
+		_, err := tx.Exec(ctx, `
+			INSERT INTO enrollment_windows (term_id, cohort, opens_at, closes_at)
+			SELECT $1::int, * FROM unnest($2::text[], $3::timestamptz[], $4::timestamptz[])`,
+			termID, cohorts, opens, closes)
+		if err != nil {
+			return err
+		}
+
+		saved, err = enrollmentWindows(ctx, tx, termID)
+		return err
+	})
+	return saved, classify(err)
+}
+
+func (r *PgWindowRepository) GrantOverride(ctx context.Context, o EnrollmentOverride) (EnrollmentOverride, error) {
+	var granted EnrollmentOverride
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if err := termExists(ctx, tx, o.TermID); err != nil {
+			return err
+		}
+		studentID, err := studentIDByEmail(ctx, tx, o.StudentEmail)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `
+			UPDATE enrollment_overrides SET revoked_by = $3, revoked_at = NOW()
+			WHERE term_id=$1 AND student_id=$2 AND revoked_at IS NULL`,
+			o.TermID, studentID, o.GrantedBy)
+		if err != nil {
+			return err
+		}
+
+		var id int
+		err = tx.QueryRow(ctx, `
+			INSERT INTO enrollment_overrides (term_id, student_id, reason, granted_by, expires_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`,
+			o.TermID, studentID, o.Reason, o.GrantedBy, o.ExpiresAt,
+		).Scan(&id)
+		if err != nil {
+			return err
+		}
+
+		granted, err = scanOverride(tx.QueryRow(ctx, overrideSelect+` WHERE ov.id=$1`, id))
+		return err
+	})
+	return granted, classify(err)
+}
+
+func (r *PgWindowRepository) ListOverrides(ctx context.Context, termID int) ([]EnrollmentOverride, error) {
+	if err := termExists(ctx, r.db, termID); err != nil {
+		return nil, classify(err)
+	}
+	rows, err := r.db.Query(ctx, overrideSelect+`
+		WHERE ov.term_id=$1
+		ORDER BY ov.created_at DESC, ov.id DESC`, termID)
+	if err != nil {
+		return nil, classify(err)
+	}
+	overrides, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (EnrollmentOverride, error) {
+		return scanOverride(row)
+	})
+	return overrides, classify(err)
+}
+
+func (r *PgWindowRepository) RevokeOverride(ctx context.Context, termID, id int, revokedBy string) (EnrollmentOverride, error) {
+	var revoked EnrollmentOverride
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `
+			UPDATE enrollment_overrides SET revoked_by = $3, revoked_at = NOW()
+			WHERE id=$1 AND term_id=$2 AND revoked_at IS NULL`,
+			id, termID, revokedBy)
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrOverrideNotFound
+		}
+
+		revoked, err = scanOverride(tx.QueryRow(ctx, overrideSelect+` WHERE ov.id=$1`, id))
+		return err
+	})
+	return revoked, classify(err)
+}
+
+func (r *PgWindowRepository) SetStudentCohort(ctx context.Context, email string, cohort *string) (StudentCohort, error) {
+	var s StudentCohort
+	err := r.db.QueryRow(ctx, `
+		UPDATE students SET cohort=$2 WHERE email=$1
+		RETURNING email, cohort`,
+		email, cohort,
+	).Scan(&s.StudentEmail, &s.Cohort)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return s, ErrStudentNotFound
+	}
+	return s, classify(err)
+}
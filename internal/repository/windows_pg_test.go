@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"elimu-go/internal/testdb"
+)
+
+func TestPgWindowRepository_CohortWindowsAndOverrides(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	testdb.Exec(t, pool, `
+		UPDATE students SET cohort = '2027' WHERE email = 'amani@student.school.edu';
+		UPDATE students SET cohort = '2029' WHERE email = 'baraka@student.school.edu'`)
+	terms := NewPgTermRepository(pool)
+	windows := NewPgWindowRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	ctx := context.Background()
+
+	offering := createOffering(t, terms, pool, "CS101", 10)
+
+	// Seniors are registering now; the 2029 cohort waits a week.
+	now := time.Now()
+	saved, err := windows.SetEnrollmentWindows(ctx, offering.TermID, []EnrollmentWindow{
+		{Cohort: "2029", OpensAt: now.AddDate(0, 0, 7), ClosesAt: now.AddDate(0, 0, 14)},
+		{Cohort: "2027", OpensAt: now.AddDate(0, 0, -1), ClosesAt: now.AddDate(0, 0, 1)},
+	})
+	if err != nil {
+		t.Fatalf("SetEnrollmentWindows: %v", err)
+	}
+	if len(saved) != 2 || saved[0].Cohort != "2027" {
+		t.Errorf("Expected both windows, earliest first, got %+v", saved)
+	}
+
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false); err != nil {
+		t.Errorf("Expected the 2027 cohort to enroll, got %v", err)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "baraka@student.school.edu", false); !errors.Is(err, ErrEnrollmentNotOpen) {
+		t.Errorf("Expected ErrEnrollmentNotOpen for the 2029 cohort, got %v", err)
+	}
+	// Students without a cohort window fall back to the term's window.
+	if _, err := enrollments.Enroll(ctx, offering.ID, "chege@student.school.edu", false); err != nil {
+		t.Errorf("Expected a student without a cohort to use the term window, got %v", err)
+	}
+
+	override, err := windows.GrantOverride(ctx, EnrollmentOverride{
+		TermID:       offering.TermID,
+		StudentEmail: "baraka@student.school.edu",
+		Reason:       "Transfer student admitted after registration",
+		GrantedBy:    "grace@school.edu",
+	})
+	if err != nil {
+		t.Fatalf("GrantOverride: %v", err)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "baraka@student.school.edu", false); err != nil {
+		t.Errorf("Expected the override to allow enrollment, got %v", err)
+	}
+
+	revoked, err := windows.RevokeOverride(ctx, offering.TermID, override.ID, "grace@school.edu")
+	if err != nil {
+		t.Fatalf("RevokeOverride: %v", err)
+	}
+	if revoked.RevokedAt == nil || revoked.RevokedBy == nil || *revoked.RevokedBy != "grace@school.edu" {
+		t.Errorf("Expected the revocation to be recorded, got %+v", revoked)
+	}
+	if _, err := windows.RevokeOverride(ctx, offering.TermID, override.ID, "grace@school.edu"); !errors.Is(err, ErrOverrideNotFound) {
+		t.Errorf("Expected ErrOverrideNotFound revoking twice, got %v", err)
+	}
+
+	overrides, err := windows.ListOverrides(ctx, offering.TermID)
+	if err != nil {
+		t.Fatalf("ListOverrides: %v", err)
+	}
+	if len(overrides) != 1 || overrides[0].Reason != "Transfer student admitted after registration" {
+		t.Errorf("Expected the revoked override to stay listed, got %+v", overrides)
+	}
+
+	if _, err := windows.GrantOverride(ctx, EnrollmentOverride{TermID: offering.TermID, StudentEmail: "nobody@school.edu", Reason: "x", GrantedBy: "grace@school.edu"}); !errors.Is(err, ErrStudentNotFound) {
+		t.Errorf("Expected ErrStudentNotFound, got %v", err)
+	}
+}
+
+func TestPgWindowRepository_SetStudentCohort(t *testing.T) {
+	pool := testdb.New(t)
+	seedStudents(t, pool)
+	terms := NewPgTermRepository(pool)
+	windows := NewPgWindowRepository(pool)
+	enrollments := NewPgEnrollmentRepository(pool)
+	ctx := context.Background()
+
+	offering := createOffering(t, terms, pool, "CS101", 10)
+	now := time.Now()
+	if _, err := windows.SetEnrollmentWindows(ctx, offering.TermID, []EnrollmentWindow{
+		{Cohort: "2029", OpensAt: now.AddDate(0, 0, 7), ClosesAt: now.AddDate(0, 0, 14)},
+	}); err != nil {
+		t.Fatalf("SetEnrollmentWindows: %v", err)
+	}
+
+	cohort := "2029"
+	assigned, err := windows.SetStudentCohort(ctx, "amani@student.school.edu", &cohort)
+	if err != nil {
+		t.Fatalf("SetStudentCohort: %v", err)
+	}
+	if assigned.StudentEmail != "amani@student.school.edu" || assigned.Cohort == nil || *assigned.Cohort != "2029" {
+		t.Errorf("Expected amani in the 2029 cohort, got %+v", assigned)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false); !errors.Is(err, ErrEnrollmentNotOpen) {
+		t.Errorf("Expected the assigned cohort's window to apply, got %v", err)
+	}
+
+	// Leaving the cohort puts the student back on the term's window.
+	cleared, err := windows.SetStudentCohort(ctx, "amani@student.school.edu", nil)
+	if err != nil {
+		t.Fatalf("SetStudentCohort nil: %v", err)
+	}
+	if cleared.Cohort != nil {
+		t.Errorf("Expected the cohort to be cleared, got %q", *cleared.Cohort)
+	}
+	if _, err := enrollments.Enroll(ctx, offering.ID, "amani@student.school.edu", false); err != nil {
+		t.Errorf("Expected the term window to apply without a cohort, got %v", err)
+	}
+
+	if _, err := windows.SetStudentCohort(ctx, "nobody@student.school.edu", &cohort); !errors.Is(err, ErrStudentNotFound) {
+		t.Errorf("Expected ErrStudentNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,256 @@
+package seed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// batchSize bounds how many upserts are sent to Postgres per round trip.
+const batchSize = 1000
+
+// Result counts the records written by Apply.
+type Result struct {
+	Students    int
+	Staff       int
+	Courses     int
+	Terms       int
+	Offerings   int
+	Enrollments int
+}
+
+// Apply upserts the expanded fixture inside tx.
+func Apply(ctx context.Context, tx pgx.Tx, f *Fixture) (Result, error) {
+	f = f.Expand()
+
+	var res Result
+	batch := &pgx.Batch{}
+
+	flush := func() error {
+		if batch.Len() == 0 {
+			return nil
+		}
+		err := tx.SendBatch(ctx, batch).Close()
+		batch = &pgx.Batch{}
+		return err
+	}
+
+	for _, s := range f.Students {
+		batch.Queue(`
+			INSERT INTO students (first_name, last_name, email, cohort)
+			VALUES ($1, $2, $3, NULLIF($4::text, ''))
+			ON CONFLICT (email) DO UPDATE
+			SET first_name = EXCLUDED.first_name,
+			    last_name = EXCLUDED.last_name,
+			    cohort = EXCLUDED.cohort`,
+			s.FirstName, s.LastName, s.Email, s.Cohort)
+		res.Students++
+		if batch.Len() >= batchSize {
+			if err := flush(); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	for _, s := range f.Staff {
+		batch.Queue(`
+			INSERT INTO staff (first_name, last_name, email, role)
+			VALUES ($1, $2, $3, NULLIF($4, ''))
+			ON CONFLICT (email) DO UPDATE
+			SET first_name = EXCLUDED.first_name,
+			    last_name = EXCLUDED.last_name,
+			    role = EXCLUDED.role`,
+			s.FirstName, s.LastName, s.Email, s.Role)
+		res.Staff++
+		if batch.Len() >= batchSize {
+			if err := flush(); err != nil {
+				return res, err
+			}
+		}
+	}
+
+	if err := flush(); err != nil {
+		return res, err
+	}
+
+	// Catalog fixtures are few, so they are written one at a time to report
+	// which record refers to a missing course, term or user.
+	for i, c := range f.Courses {
+		if err := applyCourse(ctx, tx, c); err != nil {
+			return res, fmt.Errorf("courses[%d]: %w", i, err)
+		}
+		res.Courses++
+	}
+	for i, t := range f.Terms {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO terms (code, name, starts_on, ends_on,
+				enrollment_opens_at, enrollment_closes_at, add_drop_deadline)
+			VALUES ($1, $2, $3, $4, $5, $6, $7)
+			ON CONFLICT (code) DO UPDATE
+			SET name = EXCLUDED.name,
+			    starts_on = EXCLUDED.starts_on,
+			    ends_on = EXCLUDED.ends_on,
+			    enrollment_opens_at = EXCLUDED.enrollment_opens_at,
+			    enrollment_closes_at = EXCLUDED.enrollment_closes_at,
+			    add_drop_deadline = EXCLUDED.add_drop_deadline`,
+			t.Code, t.Name, t.StartsOn, t.EndsOn, t.EnrollmentOpensAt, t.EnrollmentClosesAt, t.AddDropDeadline)
+		if err != nil {
+			return res, fmt.Errorf("terms[%d]: %w", i, err)
+		}
+		res.Terms++
+	}
+	for i, o := range f.Offerings {
+		if err := applyOffering(ctx, tx, o); err != nil {
+			return res, fmt.Errorf("offerings[%d]: %w", i, err)
+		}
+		res.Offerings++
+	}
+	for i, e := range f.Enrollments {
+		if err := applyEnrollment(ctx, tx, e); err != nil {
+			return res, fmt.Errorf("enrollments[%d]: %w", i, err)
+		}
+		res.Enrollments++
+	}
+	return res, nil
+}
+
+// lookupID returns the id selected by query, naming what in the error when
+// there is none.
+func lookupID(ctx context.Context, tx pgx.Tx, what, query string, args ...any) (int, error) {
+	var id int
+	err := tx.QueryRow(ctx, query, args...).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return 0, fmt.Errorf("%s not found", what)
+	}
+	return id, err
+}
+
+func staffID(ctx context.Context, tx pgx.Tx, email string) (int, error) {
+	return lookupID(ctx, tx, "staff "+email, `SELECT id FROM staff WHERE email=$1`, email)
+}
+
+// applyCourse upserts c and replaces its owners.
+func applyCourse(ctx context.Context, tx pgx.Tx, c Course) error {
+	var courseID int
+	err := tx.QueryRow(ctx, `
+		INSERT INTO courses (code, title, description, credits, department)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (code) DO UPDATE
+		SET title = EXCLUDED.title,
+		    description = EXCLUDED.description,
+		    credits = EXCLUDED.credits,
+		    department = EXCLUDED.department,
+		    updated_at = NOW()
+		RETURNING id`,
+		c.Code, c.Title, c.Description, c.Credits, c.Department,
+	).Scan(&courseID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM course_owners WHERE course_id=$1`, courseID); err != nil {
+		return err
+	}
+	for _, email := range c.Owners {
+		id, err := staffID(ctx, tx, email)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO course_owners (course_id, staff_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING`, courseID, id)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyOffering upserts o and replaces its instructors and meetings.
+func applyOffering(ctx context.Context, tx pgx.Tx, o Offering) error {
+	termID, err := lookupID(ctx, tx, "term "+o.Term, `SELECT id FROM terms WHERE code=$1`, o.Term)
+	if err != nil {
+		return err
+	}
+	courseID, err := lookupID(ctx, tx, "course "+o.Course, `SELECT id FROM courses WHERE code=$1`, o.Course)
+	if err != nil {
+		return err
+	}
+
+	var offeringID int
+	err = tx.QueryRow(ctx, `
+		INSERT INTO course_offerings (term_id, course_id, section, capacity)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (term_id, course_id, section) DO UPDATE
+		SET capacity = EXCLUDED.capacity, updated_at = NOW()
+		RETURNING id`,
+		termID, courseID, o.Section, o.Capacity,
+	).Scan(&offeringID)
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM offering_instructors WHERE offering_id=$1`, offeringID); err != nil {
+		return err
+	}
+	for _, email := range o.Instructors {
+		id, err := staffID(ctx, tx, email)
+		if err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO offering_instructors (offering_id, staff_id) VALUES ($1, $2)
+			ON CONFLICT DO NOTHING`, offeringID, id)
+		if err != nil {
+			return err
+		}
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM offering_meetings WHERE offering_id=$1`, offeringID); err != nil {
+		return err
+	}
+	for _, m := range o.Meetings {
+		_, err := tx.Exec(ctx, `
+			INSERT INTO offering_meetings (offering_id, weekday, starts_at, ends_at, room)
+			VALUES ($1, $2, $3::time, $4::time, $5)`,
+			offeringID, m.Weekday, m.StartsAt, m.EndsAt, m.Room)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyEnrollment upserts the student's live enrollment in the offering.
+func applyEnrollment(ctx context.Context, tx pgx.Tx, e Enrollment) error {
+	offeringID, err := lookupID(ctx, tx,
+		fmt.Sprintf("offering %s %s section %s", e.Term, e.Course, e.Section), `
+		SELECT o.id FROM course_offerings o
+		JOIN terms t ON t.id = o.term_id
+		JOIN courses c ON c.id = o.course_id
+		WHERE t.code=$1 AND c.code=$2 AND o.section=$3`,
+		e.Term, e.Course, e.Section)
+	if err != nil {
+		return err
+	}
+	studentID, err := lookupID(ctx, tx, "student "+e.Student, `SELECT id FROM students WHERE email=$1`, e.Student)
+	if err != nil {
+		return err
+	}
+
+	status := e.Status
+	if status == "" {
+		status = "enrolled"
+	}
+	_, err = tx.Exec(ctx, `
+		INSERT INTO enrollments (offering_id, student_id, status, enrolled_at)
+		VALUES ($1, $2, $3::text, CASE WHEN $3::text = 'enrolled' THEN NOW() END)
+		ON CONFLICT (offering_id, student_id) WHERE status <> 'dropped' DO UPDATE
+		SET status = EXCLUDED.status,
+		    enrolled_at = CASE WHEN EXCLUDED.status = 'enrolled'
+		        THEN COALESCE(enrollments.enrolled_at, EXCLUDED.enrolled_at) END`,
+		offeringID, studentID, status)
+	return err
+}
@@ -0,0 +1,78 @@
+package seed
+
+import (
+	"context"
+	"testing"
+
+	"elimu-go/internal/testdb"
+
+	"github.com/jackc/pgx/v5"
+)
+
+func TestApply_DemoIsIdempotent(t *testing.T) {
+	pool := testdb.New(t)
+	ctx := context.Background()
+
+	fixture, err := LoadProfile("demo")
+	if err != nil {
+		t.Fatalf("LoadProfile: %v", err)
+	}
+
+	apply := func() Result {
+		t.Helper()
+		var res Result
+		err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+			res, err = Apply(ctx, tx, fixture)
+			return err
+		})
+		if err != nil {
+			t.Fatalf("Apply: %v", err)
+		}
+		return res
+	}
+
+	first := apply()
+	if first.Courses != 3 || first.Terms != 1 || first.Offerings != 3 || first.Enrollments != 4 {
+		t.Errorf("Unexpected counts %+v", first)
+	}
+	apply()
+
+	counts := map[string]int{
+		"courses":              3,
+		"course_owners":        2,
+		"terms":                1,
+		"course_offerings":     3,
+		"offering_instructors": 2,
+		"offering_meetings":    5,
+		"enrollments":          4,
+	}
+	for table, want := range counts {
+		var got int
+		if err := pool.QueryRow(ctx, "SELECT COUNT(*) FROM "+table).Scan(&got); err != nil {
+			t.Fatalf("count %s: %v", table, err)
+		}
+		if got != want {
+			t.Errorf("Expected %d rows in %s after seeding twice, got %d", want, table, got)
+		}
+	}
+
+	var waitlisted int
+	pool.QueryRow(ctx, `SELECT COUNT(*) FROM enrollments WHERE status = 'waitlisted' AND enrolled_at IS NULL`).Scan(&waitlisted)
+	if waitlisted != 1 {
+		t.Errorf("Expected one waitlisted enrollment, got %d", waitlisted)
+	}
+}
+
+func TestApply_MissingReference(t *testing.T) {
+	pool := testdb.New(t)
+	ctx := context.Background()
+
+	fixture := &Fixture{Offerings: []Offering{{Term: "NOPE", Course: "CS101", Section: "A"}}}
+	err := pgx.BeginFunc(ctx, pool, func(tx pgx.Tx) error {
+		_, err := Apply(ctx, tx, fixture)
+		return err
+	})
+	if err == nil || err.Error() != "offerings[0]: term NOPE not found" {
+		t.Errorf("Expected the missing term to be named, got %v", err)
+	}
+}
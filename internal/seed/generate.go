@@ -0,0 +1,58 @@
+package seed
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+)
+
+var (
+	firstNames = []string{
+		"Amani", "Baraka", "Chege", "Dalia", "Eshe", "Faraji", "Gathoni", "Hamisi",
+		"Imani", "Jabari", "Kamau", "Lulu", "Makena", "Nia", "Otieno", "Pendo",
+		"Rehema", "Sefu", "Tatu", "Wanjiru", "Zuri",
+	}
+	lastNames = []string{
+		"Achieng", "Kariuki", "Mutua", "Njoroge", "Odhiambo", "Wambui", "Kiprono",
+		"Mwangi", "Nyambura", "Omondi", "Chebet", "Kilonzo", "Wekesa", "Atieno",
+	}
+	staffRoles = []string{"teacher", "teacher", "teacher", "invigilator", "registrar"}
+)
+
+// GenerateUsers deterministically creates g.Students students and g.Staff
+// staff members. Emails are numbered so they never collide.
+func GenerateUsers(g Generate) ([]Student, []Staff) {
+	rng := rand.New(rand.NewPCG(g.Seed, g.Seed^0x9e3779b97f4a7c15))
+
+	domain := g.Domain
+	if domain == "" {
+		domain = "example.edu"
+	}
+
+	name := func() (string, string) {
+		return firstNames[rng.IntN(len(firstNames))], lastNames[rng.IntN(len(lastNames))]
+	}
+	email := func(kind string, first, last string, i int) string {
+		return fmt.Sprintf("%s.%s.%s%d@%s",
+			strings.ToLower(first), strings.ToLower(last), kind, i+1, domain)
+	}
+
+	students := make([]Student, g.Students)
+	for i := range students {
+		first, last := name()
+		students[i] = Student{FirstName: first, LastName: last, Email: email("s", first, last, i)}
+	}
+
+	staff := make([]Staff, g.Staff)
+	for i := range staff {
+		first, last := name()
+		staff[i] = Staff{
+			FirstName: first,
+			LastName:  last,
+			Email:     email("t", first, last, i),
+			Role:      staffRoles[rng.IntN(len(staffRoles))],
+		}
+	}
+
+	return students, staff
+}
@@ -0,0 +1,271 @@
+// Package seed loads fixture sets into the database. Users are upserted by
+// email, courses by code, terms by code and offerings by term, course and
+// section, so seeding the same profile twice leaves the data unchanged.
+package seed
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"go.yaml.in/yaml/v3"
+)
+
+//go:embed fixtures/*.yaml
+var fixtureFiles embed.FS
+
+// Student is a pre-registered student fixture.
+type Student struct {
+	FirstName string `json:"first_name" yaml:"first_name"`
+	LastName  string `json:"last_name" yaml:"last_name"`
+	Email     string `json:"email" yaml:"email"`
+
+	// Cohort is the student's registration priority group, if any.
+	Cohort string `json:"cohort,omitempty" yaml:"cohort,omitempty"`
+}
+
+// Staff is a pre-registered staff fixture.
+type Staff struct {
+	FirstName string `json:"first_name" yaml:"first_name"`
+	LastName  string `json:"last_name" yaml:"last_name"`
+	Email     string `json:"email" yaml:"email"`
+	Role      string `json:"role" yaml:"role"`
+}
+
+// Course is a catalog course. Owners are staff emails.
+type Course struct {
+	Code        string   `json:"code" yaml:"code"`
+	Title       string   `json:"title" yaml:"title"`
+	Description string   `json:"description,omitempty" yaml:"description,omitempty"`
+	Credits     int      `json:"credits" yaml:"credits"`
+	Department  string   `json:"department" yaml:"department"`
+	Owners      []string `json:"owners,omitempty" yaml:"owners,omitempty"`
+}
+
+// Term is an academic term. StartsOn and EndsOn are dates such as
+// "2027-01-11".
+type Term struct {
+	Code               string    `json:"code" yaml:"code"`
+	Name               string    `json:"name" yaml:"name"`
+	StartsOn           string    `json:"starts_on" yaml:"starts_on"`
+	EndsOn             string    `json:"ends_on" yaml:"ends_on"`
+	EnrollmentOpensAt  time.Time `json:"enrollment_opens_at" yaml:"enrollment_opens_at"`
+	EnrollmentClosesAt time.Time `json:"enrollment_closes_at" yaml:"enrollment_closes_at"`
+	AddDropDeadline    time.Time `json:"add_drop_deadline" yaml:"add_drop_deadline"`
+}
+
+// Offering is a section of a course in a term, named by term and course
+// code. Instructors are staff emails.
+type Offering struct {
+	Term        string    `json:"term" yaml:"term"`
+	Course      string    `json:"course" yaml:"course"`
+	Section     string    `json:"section" yaml:"section"`
+	Capacity    int       `json:"capacity" yaml:"capacity"`
+	Instructors []string  `json:"instructors,omitempty" yaml:"instructors,omitempty"`
+	Meetings    []Meeting `json:"meetings,omitempty" yaml:"meetings,omitempty"`
+}
+
+// Meeting is a weekly class meeting. Weekday follows ISO 8601, 1 being
+// Monday; times are "HH:MM".
+type Meeting struct {
+	Weekday  int    `json:"weekday" yaml:"weekday"`
+	StartsAt string `json:"starts_at" yaml:"starts_at"`
+	EndsAt   string `json:"ends_at" yaml:"ends_at"`
+	Room     string `json:"room,omitempty" yaml:"room,omitempty"`
+}
+
+// Enrollment places a student, by email, in an offering. Status is
+// "enrolled" (the default) or "waitlisted". Seeding does not check
+// capacity, enrollment windows or timetable clashes.
+type Enrollment struct {
+	Student string `json:"student" yaml:"student"`
+	Term    string `json:"term" yaml:"term"`
+	Course  string `json:"course" yaml:"course"`
+	Section string `json:"section" yaml:"section"`
+	Status  string `json:"status,omitempty" yaml:"status,omitempty"`
+}
+
+// Generate asks for synthetic users on top of the listed fixtures. The same
+// Seed always produces the same users.
+type Generate struct {
+	Students int    `json:"students" yaml:"students"`
+	Staff    int    `json:"staff" yaml:"staff"`
+	Seed     uint64 `json:"seed" yaml:"seed"`
+	Domain   string `json:"domain" yaml:"domain"`
+}
+
+// Fixture is a named set of records to load.
+type Fixture struct {
+	Students    []Student    `json:"students" yaml:"students"`
+	Staff       []Staff      `json:"staff" yaml:"staff"`
+	Courses     []Course     `json:"courses" yaml:"courses"`
+	Terms       []Term       `json:"terms" yaml:"terms"`
+	Offerings   []Offering   `json:"offerings" yaml:"offerings"`
+	Enrollments []Enrollment `json:"enrollments" yaml:"enrollments"`
+	Generate    *Generate    `json:"generate" yaml:"generate"`
+}
+
+// Profiles lists the built-in fixture profiles.
+func Profiles() []string {
+	entries, _ := fs.ReadDir(fixtureFiles, "fixtures")
+	var names []string
+	for _, e := range entries {
+		names = append(names, strings.TrimSuffix(e.Name(), ".yaml"))
+	}
+	return names
+}
+
+// LoadProfile returns a built-in fixture profile such as "demo".
+func LoadProfile(name string) (*Fixture, error) {
+	data, err := fixtureFiles.ReadFile("fixtures/" + name + ".yaml")
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("unknown profile %q (available: %s)", name, strings.Join(Profiles(), ", "))
+	}
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, ".yaml")
+}
+
+// LoadFile reads a fixture from a .yaml, .yml or .json file.
+func LoadFile(path string) (*Fixture, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return Parse(data, filepath.Ext(path))
+}
+
+// Parse decodes a fixture. ext selects the format and must be .json, .yaml
+// or .yml.
+func Parse(data []byte, ext string) (*Fixture, error) {
+	var f Fixture
+	switch strings.ToLower(ext) {
+	case ".json":
+		dec := json.NewDecoder(bytes.NewReader(data))
+		dec.DisallowUnknownFields()
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+	case ".yaml", ".yml":
+		dec := yaml.NewDecoder(bytes.NewReader(data))
+		dec.KnownFields(true)
+		if err := dec.Decode(&f); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported fixture format %q", ext)
+	}
+	return &f, f.Validate()
+}
+
+// Validate checks that every record can be inserted.
+func (f *Fixture) Validate() error {
+	var problems []string
+	for i, s := range f.Students {
+		if s.Email == "" || s.FirstName == "" || s.LastName == "" {
+			problems = append(problems, fmt.Sprintf("students[%d]: first_name, last_name and email are required", i))
+		}
+		if len(s.Cohort) > 20 {
+			problems = append(problems, fmt.Sprintf("students[%d]: cohort must be at most 20 characters", i))
+		}
+	}
+	for i, s := range f.Staff {
+		if s.Email == "" || s.FirstName == "" || s.LastName == "" {
+			problems = append(problems, fmt.Sprintf("staff[%d]: first_name, last_name and email are required", i))
+		}
+	}
+	for i, c := range f.Courses {
+		if c.Code == "" || c.Title == "" || c.Department == "" {
+			problems = append(problems, fmt.Sprintf("courses[%d]: code, title and department are required", i))
+		}
+		if c.Credits < 0 || c.Credits > 60 {
+			problems = append(problems, fmt.Sprintf("courses[%d]: credits must be between 0 and 60", i))
+		}
+	}
+	for i, t := range f.Terms {
+		problems = append(problems, validateTerm(fmt.Sprintf("terms[%d]", i), t)...)
+	}
+	for i, o := range f.Offerings {
+		field := fmt.Sprintf("offerings[%d]", i)
+		if o.Term == "" || o.Course == "" || o.Section == "" {
+			problems = append(problems, field+": term, course and section are required")
+		}
+		if o.Capacity < 0 {
+			problems = append(problems, field+": capacity must not be negative")
+		}
+		for j, m := range o.Meetings {
+			if !validMeeting(m) {
+				problems = append(problems, fmt.Sprintf("%s.meetings[%d]: weekday must be 1-7 and starts_at before ends_at, as HH:MM", field, j))
+			}
+		}
+	}
+	for i, e := range f.Enrollments {
+		if e.Student == "" || e.Term == "" || e.Course == "" || e.Section == "" {
+			problems = append(problems, fmt.Sprintf("enrollments[%d]: student, term, course and section are required", i))
+		}
+		if e.Status != "" && e.Status != "enrolled" && e.Status != "waitlisted" {
+			problems = append(problems, fmt.Sprintf("enrollments[%d]: status must be enrolled or waitlisted", i))
+		}
+	}
+	if g := f.Generate; g != nil && (g.Students < 0 || g.Staff < 0) {
+		problems = append(problems, "generate: counts must not be negative")
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
+
+func validateTerm(field string, t Term) []string {
+	var problems []string
+	if t.Code == "" || t.Name == "" {
+		problems = append(problems, field+": code and name are required")
+	}
+	startsOn, err1 := time.Parse(time.DateOnly, t.StartsOn)
+	endsOn, err2 := time.Parse(time.DateOnly, t.EndsOn)
+	if err1 != nil || err2 != nil || !endsOn.After(startsOn) {
+		problems = append(problems, field+": starts_on and ends_on must be dates, in order")
+	}
+	if t.EnrollmentOpensAt.IsZero() || !t.EnrollmentClosesAt.After(t.EnrollmentOpensAt) {
+		problems = append(problems, field+": enrollment_opens_at must be before enrollment_closes_at")
+	}
+	if t.AddDropDeadline.IsZero() {
+		problems = append(problems, field+": add_drop_deadline is required")
+	}
+	return problems
+}
+
+func validMeeting(m Meeting) bool {
+	starts, err1 := time.Parse("15:04", m.StartsAt)
+	ends, err2 := time.Parse("15:04", m.EndsAt)
+	return m.Weekday >= 1 && m.Weekday <= 7 && err1 == nil && err2 == nil && ends.After(starts)
+}
+
+// Expand returns the fixture with generated users appended to the listed
+// ones.
+func (f *Fixture) Expand() *Fixture {
+	out := &Fixture{
+		Students: append([]Student(nil), f.Students...),
+		Staff:    append([]Staff(nil), f.Staff...),
+
+		Courses:     f.Courses,
+		Terms:       f.Terms,
+		Offerings:   f.Offerings,
+		Enrollments: f.Enrollments,
+	}
+	if f.Generate != nil {
+		students, staff := GenerateUsers(*f.Generate)
+		out.Students = append(out.Students, students...)
+		out.Staff = append(out.Staff, staff...)
+	}
+	return out
+}
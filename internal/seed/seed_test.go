@@ -0,0 +1,146 @@
+package seed
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestProfilesLoad(t *testing.T) {
+	for _, name := range []string{"demo", "e2e", "load-test"} {
+		if _, err := LoadProfile(name); err != nil {
+			t.Errorf("Profile %s should load: %v", name, err)
+		}
+	}
+
+	if _, err := LoadProfile("production"); err == nil {
+		t.Error("Unknown profile should be rejected")
+	}
+}
+
+func TestParse_JSONAndYAML(t *testing.T) {
+	yamlFixture, err := Parse([]byte(`
+staff:
+  - first_name: Grace
+    last_name: Mwangi
+    email: grace@school.edu
+    role: admin
+`), ".yaml")
+	if err != nil {
+		t.Fatalf("YAML fixture should parse: %v", err)
+	}
+
+	jsonFixture, err := Parse([]byte(`{
+		"staff": [{"first_name": "Grace", "last_name": "Mwangi", "email": "grace@school.edu", "role": "admin"}]
+	}`), ".json")
+	if err != nil {
+		t.Fatalf("JSON fixture should parse: %v", err)
+	}
+
+	if !reflect.DeepEqual(yamlFixture, jsonFixture) {
+		t.Errorf("YAML and JSON fixtures differ:\n%+v\n%+v", yamlFixture, jsonFixture)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	cases := map[string]string{
+		"missing email": "students:\n  - first_name: A\n    last_name: B\n",
+		"unknown field": "teachers:\n  - email: a@b.c\n",
+		"negative":      "generate:\n  students: -1\n",
+		"long cohort":   "students:\n  - first_name: A\n    last_name: B\n    email: a@b.c\n    cohort: class-of-twenty-twenty-seven\n",
+		"credits":       "courses:\n  - {code: CS1, title: T, department: D, credits: 61}\n",
+		"term dates":    "terms:\n  - {code: T, name: T, starts_on: 2027-05-01, ends_on: 2027-01-01}\n",
+		"meeting":       "offerings:\n  - {term: T, course: C, section: A, meetings: [{weekday: 8, starts_at: '09:00', ends_at: '10:00'}]}\n",
+		"status":        "enrollments:\n  - {student: a@b.c, term: T, course: C, section: A, status: dropped}\n",
+	}
+	for name, data := range cases {
+		if _, err := Parse([]byte(data), ".yaml"); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+
+	if _, err := Parse([]byte("{}"), ".toml"); err == nil {
+		t.Error("Unsupported format should be rejected")
+	}
+}
+
+func TestParse_Catalog(t *testing.T) {
+	yamlFixture, err := Parse([]byte(`
+terms:
+  - code: 2027-SP
+    name: Spring 2027
+    starts_on: 2027-01-11
+    ends_on: 2027-05-07
+    enrollment_opens_at: 2026-10-01T06:00:00Z
+    enrollment_closes_at: 2027-01-22T21:00:00Z
+    add_drop_deadline: 2027-01-29T21:00:00Z
+offerings:
+  - term: 2027-SP
+    course: CS101
+    section: A
+    capacity: 30
+    meetings:
+      - {weekday: 1, starts_at: "09:00", ends_at: "10:30", room: LAB-1}
+`), ".yaml")
+	if err != nil {
+		t.Fatalf("YAML fixture should parse: %v", err)
+	}
+
+	jsonFixture, err := Parse([]byte(`{
+		"terms": [{"code": "2027-SP", "name": "Spring 2027", "starts_on": "2027-01-11", "ends_on": "2027-05-07",
+			"enrollment_opens_at": "2026-10-01T06:00:00Z", "enrollment_closes_at": "2027-01-22T21:00:00Z",
+			"add_drop_deadline": "2027-01-29T21:00:00Z"}],
+		"offerings": [{"term": "2027-SP", "course": "CS101", "section": "A", "capacity": 30,
+			"meetings": [{"weekday": 1, "starts_at": "09:00", "ends_at": "10:30", "room": "LAB-1"}]}]
+	}`), ".json")
+	if err != nil {
+		t.Fatalf("JSON fixture should parse: %v", err)
+	}
+
+	if !reflect.DeepEqual(yamlFixture, jsonFixture) {
+		t.Errorf("YAML and JSON fixtures differ:\n%+v\n%+v", yamlFixture, jsonFixture)
+	}
+}
+
+func TestGenerateUsers_Deterministic(t *testing.T) {
+	g := Generate{Students: 50, Staff: 10, Seed: 42}
+
+	s1, t1 := GenerateUsers(g)
+	s2, t2 := GenerateUsers(g)
+	if !reflect.DeepEqual(s1, s2) || !reflect.DeepEqual(t1, t2) {
+		t.Error("Same seed should generate the same users")
+	}
+
+	s3, _ := GenerateUsers(Generate{Students: 50, Seed: 43})
+	if reflect.DeepEqual(s1, s3) {
+		t.Error("Different seeds should generate different users")
+	}
+
+	seen := make(map[string]bool)
+	for _, s := range s1 {
+		if seen[s.Email] {
+			t.Errorf("Duplicate generated email %s", s.Email)
+		}
+		seen[s.Email] = true
+	}
+	for _, s := range t1 {
+		if seen[s.Email] {
+			t.Errorf("Duplicate generated email %s", s.Email)
+		}
+		seen[s.Email] = true
+	}
+}
+
+func TestExpand(t *testing.T) {
+	f := &Fixture{
+		Students: []Student{{FirstName: "A", LastName: "B", Email: "a@b.c"}},
+		Generate: &Generate{Students: 3, Staff: 2, Seed: 1},
+	}
+
+	out := f.Expand()
+	if len(out.Students) != 4 || len(out.Staff) != 2 {
+		t.Errorf("Expected 4 students and 2 staff, got %d and %d", len(out.Students), len(out.Staff))
+	}
+	if len(f.Students) != 1 {
+		t.Error("Expand should not modify the original fixture")
+	}
+}
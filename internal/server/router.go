@@ -0,0 +1,245 @@
+// Package server wires handlers, middleware and storage into an HTTP
+// server. Tests build the same router with fakes via NewRouter.
+package server
+
+import (
+	"elimu-go/internal/config"
+	"elimu-go/internal/handlers"
+	"elimu-go/internal/health"
+	"elimu-go/internal/metrics"
+	"elimu-go/internal/middleware"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+	"elimu-go/internal/tracing"
+
+	_ "elimu-go/docs"
+
+	"github.com/gin-gonic/gin"
+	swaggerFiles "github.com/swaggo/files"
+	ginSwagger "github.com/swaggo/gin-swagger"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	"golang.org/x/oauth2"
+)
+
+// Deps are the collaborators the router's handlers need.
+type Deps struct {
+	// Env is the config.Env the API runs in.
+	Env            string
+	CORS           config.CORS
+	TrustedProxies []string
+
+	// InternalRoutes is a config.InternalRoutes value. Empty means off.
+	InternalRoutes string
+
+	Users         repository.UserRepository
+	Sessions      repository.SessionRepository
+	Roles         repository.RoleRepository
+	Courses       repository.CourseRepository
+	Requisites    repository.RequisiteRepository
+	Records       repository.RecordRepository
+	Terms         repository.TermRepository
+	Windows       repository.WindowRepository
+	Enrollments   repository.EnrollmentRepository
+	Roster        repository.RosterRepository
+	Notifications repository.NotificationRepository
+	OAuth         *oauth2.Config
+
+	// Health holds the readiness checks. Nil means no checks.
+	Health *health.Registry
+
+	// Metrics collects Prometheus metrics. Nil means a fresh set without
+	// database pool stats.
+	Metrics *metrics.Metrics
+}
+
+// Handlers are the handler instances behind a router, for callers that
+// need to start their background work.
+type Handlers struct {
+	Auth        *handlers.AuthHandler
+	Admin       *handlers.AdminHandler
+	Health      *handlers.HealthHandler
+	Courses     *handlers.CourseHandler
+	Terms       *handlers.TermHandler
+	Enrollments *handlers.EnrollmentHandler
+	Roster      *handlers.RosterHandler
+}
+
+// adminRoles may use the admin API.
+var adminRoles = []string{"admin", "cto"}
+
+// courseStaffRoles may manage the course catalog. Everyone logged in may
+// read it.
+var courseStaffRoles = []string{"teacher", "registrar", "admin", "cto"}
+
+// registrarRoles may manage terms and schedule course offerings.
+var registrarRoles = []string{"registrar", "admin", "cto"}
+
+// NewRouter registers every route on a new gin engine.
+func NewRouter(d Deps) (*gin.Engine, *Handlers, error) {
+	if d.Health == nil {
+		d.Health = health.NewRegistry()
+	}
+	if d.Metrics == nil {
+		d.Metrics = metrics.New()
+	}
+	d.Metrics.SessionCount(func() int { return len(d.Sessions.List()) })
+
+	h := &Handlers{
+		Auth:        handlers.NewAuthHandler(d.Users, d.Sessions, d.OAuth, d.Metrics),
+		Admin:       handlers.NewAdminHandler(d.Users, d.Sessions, d.Roles),
+		Health:      handlers.NewHealthHandler(d.Health),
+		Courses:     handlers.NewCourseHandler(d.Courses, d.Requisites, d.Records),
+		Terms:       handlers.NewTermHandler(d.Terms, d.Windows),
+		Enrollments: handlers.NewEnrollmentHandler(d.Enrollments, d.Notifications),
+		Roster:      handlers.NewRosterHandler(d.Roster, d.Terms),
+	}
+
+	r := gin.New()
+	if err := r.SetTrustedProxies(d.TrustedProxies); err != nil {
+		return nil, nil, err
+	}
+	r.Use(
+		otelgin.Middleware(tracing.ServiceName),
+		middleware.RequestID(),
+		middleware.AccessLog(),
+		d.Metrics.Middleware(),
+		middleware.Recovery(),
+		middleware.SecurityHeaders(d.Env == config.EnvProduction),
+		middleware.CORS(d.CORS),
+		middleware.IdentifyUser(d.Sessions),
+	)
+	r.NoRoute(problem.NotFoundHandler)
+	r.GET("/metrics", gin.WrapH(d.Metrics.Handler()))
+
+	api := r.Group("/api")
+	{
+		api.GET("/", handlers.Welcome)
+		// /health predates the probes; monitors still polling it get
+		// the readiness report.
+		api.GET("/health", h.Health.Ready)
+		api.GET("/health/live", h.Health.Live)
+		api.GET("/health/ready", h.Health.Ready)
+		api.GET("/random", handlers.RandomEndpoint)
+		api.GET("/login", h.Auth.GoogleLogin)
+		api.GET("/callback", h.Auth.GoogleCallback)
+		api.GET("/me", h.Auth.GetCurrentUser)
+		api.GET("/logout", h.Auth.Logout)
+	}
+
+	admin := api.Group("/admin")
+	admin.Use(
+		middleware.RequireLogin(d.Sessions),
+		middleware.RequireRole(adminRoles...),
+	)
+	{
+		admin.GET("/overview", h.Admin.AdminOverview)
+		admin.GET("/users/export", h.Admin.ExportUsers)
+		admin.GET("/roles/grants", h.Admin.ListRoleGrants)
+		admin.POST("/roles/grants", h.Admin.GrantRole)
+		admin.DELETE("/roles/grants/:id", h.Admin.RevokeRoleGrant)
+		admin.GET("/roles/requests", h.Admin.ListRoleRequests)
+		admin.POST("/roles/requests/:id/approve", h.Admin.ApproveRoleRequest)
+		admin.POST("/roles/requests/:id/reject", h.Admin.RejectRoleRequest)
+	}
+
+	courses := api.Group("/courses")
+	courses.Use(middleware.RequireLogin(d.Sessions))
+	{
+		courses.GET("", h.Courses.ListCourses)
+		courses.GET("/search", h.Courses.SearchCourses)
+		courses.GET("/:id", h.Courses.GetCourse)
+		courses.GET("/:id/requisites", h.Courses.GetRequisites)
+		courses.GET("/:id/eligibility", h.Courses.CheckEligibility)
+
+		staff := courses.Group("", middleware.RequireRole(courseStaffRoles...))
+		staff.POST("", h.Courses.CreateCourse)
+		staff.PUT("/:id", h.Courses.UpdateCourse)
+		staff.DELETE("/:id", h.Courses.DeleteCourse)
+		staff.PUT("/:id/requisites", h.Courses.SetRequisites)
+		staff.POST("/:id/grades", h.Courses.RecordGrade)
+	}
+
+	terms := api.Group("/terms")
+	terms.Use(middleware.RequireLogin(d.Sessions))
+	{
+		terms.GET("", h.Terms.ListTerms)
+		terms.GET("/:id", h.Terms.GetTerm)
+		terms.GET("/:id/offerings", h.Terms.ListOfferings)
+		terms.GET("/:id/windows", h.Terms.ListEnrollmentWindows)
+
+		registrar := terms.Group("", middleware.RequireRole(registrarRoles...))
+		registrar.POST("", h.Terms.CreateTerm)
+		registrar.PUT("/:id", h.Terms.UpdateTerm)
+		registrar.POST("/:id/offerings", h.Terms.CreateOffering)
+		registrar.POST("/:id/offerings/clone", h.Terms.CloneOfferings)
+		registrar.PUT("/:id/windows", h.Terms.SetEnrollmentWindows)
+		registrar.GET("/:id/roster-changes", h.Roster.ListTermRosterChanges)
+
+		admin := terms.Group("", middleware.RequireRole(adminRoles...))
+		admin.GET("/:id/overrides", h.Terms.ListEnrollmentOverrides)
+		admin.POST("/:id/overrides", h.Terms.GrantEnrollmentOverride)
+		admin.DELETE("/:id/overrides/:override", h.Terms.RevokeEnrollmentOverride)
+	}
+
+	offerings := api.Group("/offerings")
+	offerings.Use(middleware.RequireLogin(d.Sessions))
+	{
+		offerings.GET("/:id", h.Terms.GetOffering)
+
+		registrar := offerings.Group("", middleware.RequireRole(registrarRoles...))
+		registrar.PUT("/:id", h.Terms.UpdateOffering)
+		registrar.DELETE("/:id", h.Terms.DeleteOffering)
+
+		student := offerings.Group("", middleware.RequireRole("student"))
+		student.POST("/:id/enrollment", h.Enrollments.Enroll)
+		student.DELETE("/:id/enrollment", h.Enrollments.Drop)
+
+		// Instructors may only manage the rosters of offerings they teach.
+		staff := offerings.Group("/:id/roster", middleware.RequireRole(courseStaffRoles...))
+		staff.GET("", h.Roster.GetRoster)
+		staff.GET("/export", h.Roster.ExportRoster)
+		staff.GET("/changes", h.Roster.ListRosterChanges)
+		staff.POST("", h.Roster.AddToRoster)
+		staff.DELETE("/:enrollment", h.Roster.RemoveFromRoster)
+	}
+
+	students := api.Group("/students")
+	students.Use(
+		middleware.RequireLogin(d.Sessions),
+		middleware.RequireRole(registrarRoles...),
+	)
+	{
+		students.PUT("/cohort", h.Terms.SetStudentCohort)
+	}
+
+	me := api.Group("/me")
+	me.Use(middleware.RequireLogin(d.Sessions))
+	{
+		me.GET("/enrollments", h.Enrollments.MyEnrollments)
+		me.GET("/timetable", h.Enrollments.MyTimetable)
+		me.GET("/notifications", h.Enrollments.MyNotifications)
+		me.POST("/notifications/:id/read", h.Enrollments.MarkNotificationRead)
+	}
+
+	// Debug output and API docs help during development but map the API
+	// out for attackers, so production hides them or keeps them for admins.
+	var internal gin.IRoutes
+	switch d.InternalRoutes {
+	case config.InternalRoutesPublic:
+		internal = r
+	case config.InternalRoutesAdmin:
+		internal = r.Group("",
+			middleware.RequireLogin(d.Sessions),
+			middleware.RequireRole(adminRoles...),
+		)
+	}
+	if internal != nil {
+		internal.GET("/api/debug", handlers.DebugInfo)
+		internal.GET("/swagger/*any",
+			middleware.ContentSecurityPolicy(middleware.SwaggerContentSecurityPolicy),
+			ginSwagger.WrapHandler(swaggerFiles.Handler),
+		)
+	}
+
+	return r, h, nil
+}
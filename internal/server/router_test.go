@@ -0,0 +1,261 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/config"
+	"elimu-go/internal/health"
+	"elimu-go/internal/models"
+	"elimu-go/internal/problem"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/oauth2"
+)
+
+// newTestServer serves a router over in-memory repositories. configure,
+// if given, adjusts the dependencies first.
+func newTestServer(t *testing.T, configure ...func(*Deps)) (*httptest.Server, *repository.MemorySessionRepository) {
+	gin.SetMode(gin.TestMode)
+
+	users := repository.NewMemoryUserRepository()
+	users.AddStudent(repository.StudentRow{FirstName: "Amani", Email: "amani@school.edu"})
+	sessions := repository.NewMemorySessionRepository()
+
+	d := Deps{
+		Users:    users,
+		Sessions: sessions,
+		OAuth:    &oauth2.Config{},
+	}
+	for _, fn := range configure {
+		fn(&d)
+	}
+
+	router, _, err := NewRouter(d)
+	if err != nil {
+		t.Fatalf("NewRouter: %v", err)
+	}
+
+	srv := httptest.NewServer(router)
+	t.Cleanup(srv.Close)
+	return srv, sessions
+}
+
+func get(t *testing.T, url, sessionID string) *http.Response {
+	t.Helper()
+
+	req, _ := http.NewRequest("GET", url, nil)
+	if sessionID != "" {
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: sessionID})
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET %s: %v", url, err)
+	}
+	resp.Body.Close()
+	return resp
+}
+
+func TestRouter_PublicRoutes(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	for _, path := range []string{"/api/", "/api/health", "/api/random"} {
+		if resp := get(t, srv.URL+path, ""); resp.StatusCode != http.StatusOK {
+			t.Errorf("GET %s: expected 200, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestRouter_LegacyHealthRunsChecks(t *testing.T) {
+	srv, _ := newTestServer(t, func(d *Deps) {
+		d.Health = health.NewRegistry()
+		d.Health.Register(health.Check{
+			Name:     "postgres",
+			Critical: true,
+			Run:      func(context.Context) error { return errors.New("connection refused") },
+		})
+	})
+
+	for _, path := range []string{"/api/health", "/api/health/ready"} {
+		if resp := get(t, srv.URL+path, ""); resp.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("GET %s with postgres down: expected 503, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestRouter_AdminRequiresRole(t *testing.T) {
+	srv, sessions := newTestServer(t)
+	sessions.Save("student", &models.User{ID: "1", Email: "amani@school.edu", Role: "student"})
+	sessions.Save("admin", &models.User{ID: "2", Email: "grace@school.edu", Role: "admin"})
+
+	cases := map[string]int{
+		"":        http.StatusUnauthorized,
+		"expired": http.StatusUnauthorized,
+		"student": http.StatusForbidden,
+		"admin":   http.StatusOK,
+	}
+	for session, want := range cases {
+		resp := get(t, srv.URL+"/api/admin/overview", session)
+		if resp.StatusCode != want {
+			t.Errorf("session %q: expected %d, got %d", session, want, resp.StatusCode)
+		}
+	}
+}
+
+func TestRouter_CourseWritesRequireStaff(t *testing.T) {
+	srv, sessions := newTestServer(t)
+	sessions.Save("student", &models.User{ID: "1", Email: "amani@school.edu", Role: "student"})
+
+	for _, method := range []string{"POST", "PUT", "DELETE"} {
+		path := "/api/courses"
+		if method != "POST" {
+			path += "/1"
+		}
+		req, _ := http.NewRequest(method, srv.URL+path, strings.NewReader(`{}`))
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "student"})
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s %s: %v", method, path, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s %s as student: expected 403, got %d", method, path, resp.StatusCode)
+		}
+	}
+
+	if resp := get(t, srv.URL+"/api/courses", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected the catalog to require login, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_EnrollmentRequiresStudent(t *testing.T) {
+	srv, sessions := newTestServer(t)
+	sessions.Save("teacher", &models.User{ID: "1", Email: "ruth@school.edu", Role: "teacher"})
+
+	for _, method := range []string{"POST", "DELETE"} {
+		req, _ := http.NewRequest(method, srv.URL+"/api/offerings/1/enrollment", nil)
+		req.AddCookie(&http.Cookie{Name: "session_id", Value: "teacher"})
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("%s enrollment: %v", method, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != http.StatusForbidden {
+			t.Errorf("%s enrollment as teacher: expected 403, got %d", method, resp.StatusCode)
+		}
+	}
+
+	if resp := get(t, srv.URL+"/api/me/enrollments", ""); resp.StatusCode != http.StatusUnauthorized {
+		t.Errorf("Expected enrollments to require login, got %d", resp.StatusCode)
+	}
+}
+
+func TestRouter_RosterRequiresStaff(t *testing.T) {
+	srv, sessions := newTestServer(t)
+	sessions.Save("student", &models.User{ID: "1", Email: "amani@school.edu", Role: "student"})
+
+	for _, path := range []string{"/api/offerings/1/roster", "/api/offerings/1/roster/export", "/api/offerings/1/roster/changes"} {
+		if resp := get(t, srv.URL+path, "student"); resp.StatusCode != http.StatusForbidden {
+			t.Errorf("GET %s as student: expected 403, got %d", path, resp.StatusCode)
+		}
+	}
+}
+
+func TestRouter_Metrics(t *testing.T) {
+	srv, _ := newTestServer(t)
+	get(t, srv.URL+"/api/health", "")
+
+	resp, err := http.Get(srv.URL + "/metrics")
+	if err != nil {
+		t.Fatalf("GET /metrics: %v", err)
+	}
+	defer resp.Body.Close()
+	body, _ := io.ReadAll(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("Expected 200, got %d", resp.StatusCode)
+	}
+	want := `elimu_http_requests_total{method="GET",route="/api/health",status="200"} 1`
+	if !strings.Contains(string(body), want) {
+		t.Errorf("Expected %s in:\n%s", want, body)
+	}
+}
+
+func TestRouter_ProblemResponses(t *testing.T) {
+	srv, _ := newTestServer(t)
+
+	cases := map[string]string{
+		"/api/admin/overview": "auth.not_logged_in",
+		"/api/nowhere":        "request.no_route",
+	}
+	for path, code := range cases {
+		resp, err := http.Get(srv.URL + path)
+		if err != nil {
+			t.Fatalf("GET %s: %v", path, err)
+		}
+		var p problem.Problem
+		json.NewDecoder(resp.Body).Decode(&p)
+		resp.Body.Close()
+
+		if ct := resp.Header.Get("Content-Type"); ct != problem.ContentType {
+			t.Errorf("GET %s: expected %s, got %s", path, problem.ContentType, ct)
+		}
+		if p.Code != code || p.RequestID == "" {
+			t.Errorf("GET %s: expected code %s with a request ID, got %+v", path, code, p)
+		}
+	}
+}
+
+func TestRouter_InternalRoutes(t *testing.T) {
+	cases := map[string]map[string]int{
+		config.InternalRoutesPublic: {"": http.StatusOK, "student": http.StatusOK},
+		config.InternalRoutesAdmin:  {"": http.StatusUnauthorized, "student": http.StatusForbidden, "admin": http.StatusOK},
+		config.InternalRoutesOff:    {"": http.StatusNotFound, "admin": http.StatusNotFound},
+	}
+
+	for mode, sessionsWant := range cases {
+		srv, sessions := newTestServer(t, func(d *Deps) { d.InternalRoutes = mode })
+		sessions.Save("student", &models.User{ID: "1", Role: "student"})
+		sessions.Save("admin", &models.User{ID: "2", Role: "admin"})
+
+		for session, want := range sessionsWant {
+			for _, path := range []string{"/api/debug", "/swagger/index.html"} {
+				if resp := get(t, srv.URL+path, session); resp.StatusCode != want {
+					t.Errorf("%s mode, session %q, GET %s: expected %d, got %d", mode, session, path, want, resp.StatusCode)
+				}
+			}
+		}
+	}
+}
+
+func TestRouter_TrustedProxies(t *testing.T) {
+	srv, _ := newTestServer(t, func(d *Deps) {
+		d.InternalRoutes = config.InternalRoutesPublic
+		d.TrustedProxies = []string{"127.0.0.1"}
+	})
+
+	req, _ := http.NewRequest("GET", srv.URL+"/api/debug", nil)
+	req.Header.Set("X-Forwarded-For", "203.0.113.7")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("GET /api/debug: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var body struct{ IP string }
+	json.NewDecoder(resp.Body).Decode(&body)
+	if body.IP != "203.0.113.7" {
+		t.Errorf("Expected the forwarded client IP, got %q", body.IP)
+	}
+
+	if _, _, err := NewRouter(Deps{Sessions: repository.NewMemorySessionRepository(), TrustedProxies: []string{"not-an-ip"}}); err == nil {
+		t.Error("Expected an invalid proxy to be rejected")
+	}
+}
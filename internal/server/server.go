@@ -0,0 +1,219 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"elimu-go/database"
+	"elimu-go/internal/config"
+	"elimu-go/internal/handlers"
+	"elimu-go/internal/health"
+	"elimu-go/internal/metrics"
+	"elimu-go/internal/repository"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// roleExpiryInterval is how often expired role grants are reverted.
+	roleExpiryInterval = time.Minute
+
+	// maxPoolSaturation is the share of pool connections in use above
+	// which the instance reports itself degraded.
+	maxPoolSaturation = 0.9
+)
+
+// ginMode keeps gin's route dump and debug warnings out of production logs.
+func ginMode(env string) string {
+	switch env {
+	case config.EnvProduction:
+		return gin.ReleaseMode
+	case config.EnvTest:
+		return gin.TestMode
+	}
+	return gin.DebugMode
+}
+
+// Worker is background work that runs for the server's lifetime and must
+// return promptly once ctx is cancelled.
+type Worker func(ctx context.Context)
+
+// Server is the API server with its database pool and background work.
+type Server struct {
+	HTTP *http.Server
+
+	shutdown config.Shutdown
+	db       *pgxpool.Pool
+	workers  []Worker
+	ready    atomic.Bool
+}
+
+// NewServer connects to Postgres, optionally migrates it, and builds the
+// router. Call Close to release the pool if Run is never called.
+func NewServer(ctx context.Context, cfg *config.Config) (*Server, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	gin.SetMode(ginMode(cfg.Env))
+
+	db, err := repository.Connect(ctx, cfg.DatabaseURL.Value())
+	if err != nil {
+		return nil, fmt.Errorf("connect to database: %w", err)
+	}
+	slog.Info("postgres pool connected")
+
+	if cfg.AutoMigrate {
+		applied, err := database.MigrateUp(ctx, db)
+		if err != nil {
+			db.Close()
+			return nil, fmt.Errorf("migrate database: %w", err)
+		}
+		slog.Info("migrations applied", "count", len(applied))
+	}
+
+	s := &Server{
+		shutdown: cfg.Shutdown,
+		db:       db,
+	}
+
+	roleExpiry := &health.Heartbeat{}
+
+	checks := health.NewRegistry()
+	checks.Register(health.Check{
+		Name:     "accepting_traffic",
+		Critical: true,
+		Run: func(context.Context) error {
+			if !s.ready.Load() {
+				return errors.New("server is shutting down")
+			}
+			return nil
+		},
+	})
+	checks.Register(health.Postgres(db))
+	checks.Register(health.PoolSaturation(db, maxPoolSaturation))
+	checks.Register(health.MigrationVersion(db))
+	checks.Register(roleExpiry.Check("role_expiry_worker", 3*roleExpiryInterval))
+
+	m := metrics.New()
+	m.Pool(db)
+
+	router, h, err := NewRouter(Deps{
+		Env:            cfg.Env,
+		CORS:           cfg.CORS,
+		TrustedProxies: cfg.TrustedProxies,
+		InternalRoutes: cfg.InternalRoutes,
+		Users:          repository.NewPgUserRepository(db),
+		Sessions:       repository.NewMemorySessionRepository(),
+		Roles:          repository.NewPgRoleRepository(db),
+		Courses:        repository.NewPgCourseRepository(db),
+		Requisites:     repository.NewPgRequisiteRepository(db),
+		Records:        repository.NewPgRecordRepository(db),
+		Terms:          repository.NewPgTermRepository(db),
+		Windows:        repository.NewPgWindowRepository(db),
+		Enrollments:    repository.NewPgEnrollmentRepository(db),
+		Roster:         repository.NewPgRosterRepository(db),
+		Notifications:  repository.NewPgNotificationRepository(db),
+		OAuth:          handlers.GoogleOAuthConfig(cfg.Google),
+		Health:         checks,
+		Metrics:        m,
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("build router: %w", err)
+	}
+
+	s.HTTP = &http.Server{
+		Addr:    ":" + cfg.Port,
+		Handler: router,
+	}
+	s.workers = []Worker{
+		func(ctx context.Context) { h.Admin.RunRoleExpiry(ctx, roleExpiryInterval, roleExpiry) },
+	}
+
+	return s, nil
+}
+
+// Run serves HTTP until ctx is cancelled, then shuts down in order:
+// readiness starts failing, in-flight requests drain, background workers
+// stop, and finally the database pool is closed.
+func (s *Server) Run(ctx context.Context) error {
+	l, err := net.Listen("tcp", s.HTTP.Addr)
+	if err != nil {
+		s.Close()
+		return err
+	}
+	return s.serve(ctx, l)
+}
+
+func (s *Server) serve(ctx context.Context, l net.Listener) error {
+	defer s.Close()
+
+	workerCtx, stopWorkers := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	for _, w := range s.workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w(workerCtx)
+		}()
+	}
+	defer func() {
+		stopWorkers()
+		wg.Wait()
+		slog.Info("background workers stopped")
+	}()
+
+	served := make(chan error, 1)
+	go func() { served <- s.HTTP.Serve(l) }()
+
+	s.ready.Store(true)
+	slog.Info("listening", "addr", l.Addr().String())
+
+	select {
+	case err := <-served:
+		s.ready.Store(false)
+		return err
+	case <-ctx.Done():
+	}
+
+	slog.Info("shutting down", "drain_timeout", s.shutdown.Timeout.String())
+	s.ready.Store(false)
+
+	// Keep serving while load balancers notice readiness failing.
+	select {
+	case <-time.After(s.shutdown.Delay):
+	case err := <-served:
+		return err
+	}
+
+	drainCtx, cancel := context.WithTimeout(context.Background(), s.shutdown.Timeout)
+	defer cancel()
+
+	err := s.HTTP.Shutdown(drainCtx)
+	if serveErr := <-served; !errors.Is(serveErr, http.ErrServerClosed) {
+		err = errors.Join(err, serveErr)
+	}
+	if err != nil {
+		return fmt.Errorf("shutdown: %w", err)
+	}
+
+	slog.Info("http server drained")
+	return nil
+}
+
+// Close releases the database pool.
+func (s *Server) Close() {
+	if s.db != nil {
+		s.db.Close()
+		s.db = nil
+		slog.Info("postgres pool closed")
+	}
+}
@@ -0,0 +1,93 @@
+package server
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"elimu-go/internal/config"
+)
+
+func TestServer_GracefulShutdown(t *testing.T) {
+	s := &Server{
+		shutdown: config.Shutdown{Delay: 200 * time.Millisecond, Timeout: 5 * time.Second},
+	}
+
+	started := make(chan struct{})
+	s.HTTP = &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/ready":
+			if !s.ready.Load() {
+				w.WriteHeader(http.StatusServiceUnavailable)
+			}
+		case "/slow":
+			close(started)
+			time.Sleep(300 * time.Millisecond)
+			w.Write([]byte("submitted"))
+		}
+	})}
+
+	var workerStopped atomic.Bool
+	s.workers = []Worker{func(ctx context.Context) {
+		<-ctx.Done()
+		workerStopped.Store(true)
+	}}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	base := "http://" + l.Addr().String()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- s.serve(ctx, l) }()
+
+	waitFor(t, func() bool { return s.ready.Load() })
+
+	slow := make(chan *http.Response, 1)
+	go func() {
+		resp, err := http.Get(base + "/slow")
+		if err != nil {
+			t.Errorf("In-flight request failed: %v", err)
+		}
+		slow <- resp
+	}()
+	<-started
+
+	cancel()
+
+	// During the delay the server still answers, but reports not ready.
+	waitFor(t, func() bool { return !s.ready.Load() })
+	if resp, err := http.Get(base + "/ready"); err != nil || resp.StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("Expected readiness to fail during shutdown, got %v %v", resp, err)
+	}
+	if workerStopped.Load() {
+		t.Error("Workers should keep running until HTTP has drained")
+	}
+
+	if err := <-done; err != nil {
+		t.Fatalf("Expected clean shutdown, got %v", err)
+	}
+
+	if resp := <-slow; resp == nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("In-flight request should complete, got %v", resp)
+	}
+	if !workerStopped.Load() {
+		t.Error("Workers should be stopped when Run returns")
+	}
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("Timed out waiting for condition")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
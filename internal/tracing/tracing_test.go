@@ -0,0 +1,115 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"elimu-go/internal/config"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.37.0"
+)
+
+func newRecorder(t *testing.T) (*tracetest.SpanRecorder, *sdktrace.TracerProvider) {
+	t.Helper()
+
+	sr := tracetest.NewSpanRecorder()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+	t.Cleanup(func() { tp.Shutdown(context.Background()) })
+	return sr, tp
+}
+
+func TestQueryTracer(t *testing.T) {
+	sr, tp := newRecorder(t)
+	qt := &QueryTracer{tracer: tp.Tracer("test")}
+
+	ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{
+		SQL:  "\n\t\tSELECT role FROM staff WHERE email=$1",
+		Args: []any{"amani@school.edu"},
+	})
+	qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{CommandTag: pgconn.NewCommandTag("SELECT 1")})
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	span := spans[0]
+	if span.Name() != "db SELECT" {
+		t.Errorf("Expected span db SELECT, got %s", span.Name())
+	}
+
+	attrs := map[string]string{}
+	for _, kv := range span.Attributes() {
+		attrs[string(kv.Key)] = kv.Value.Emit()
+		if strings.Contains(kv.Value.Emit(), "amani@") {
+			t.Errorf("Query arguments must not be recorded, found %s", kv.Key)
+		}
+	}
+	if attrs[string(semconv.DBSystemNameKey)] != "postgresql" {
+		t.Errorf("Expected db.system.name postgresql, got %q", attrs[string(semconv.DBSystemNameKey)])
+	}
+	if attrs[string(semconv.DBResponseReturnedRowsKey)] != "1" {
+		t.Errorf("Expected 1 returned row, got %q", attrs[string(semconv.DBResponseReturnedRowsKey)])
+	}
+}
+
+func TestQueryTracer_Errors(t *testing.T) {
+	sr, tp := newRecorder(t)
+	qt := &QueryTracer{tracer: tp.Tracer("test")}
+
+	for _, err := range []error{pgx.ErrNoRows, errors.New("connection reset")} {
+		ctx := qt.TraceQueryStart(context.Background(), nil, pgx.TraceQueryStartData{SQL: "SELECT 1"})
+		qt.TraceQueryEnd(ctx, nil, pgx.TraceQueryEndData{Err: err})
+	}
+
+	spans := sr.Ended()
+	if spans[0].Status().Code == codes.Error {
+		t.Error("No rows should not mark the span as failed")
+	}
+	if spans[1].Status().Code != codes.Error {
+		t.Error("Expected a failed query to mark the span as failed")
+	}
+}
+
+func TestHTTPClient_NamesSpansByEndpoint(t *testing.T) {
+	sr, tp := newRecorder(t)
+	prev := otel.GetTracerProvider()
+	otel.SetTracerProvider(tp)
+	t.Cleanup(func() { otel.SetTracerProvider(prev) })
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	resp, err := HTTPClient().Get(srv.URL + "/token")
+	if err != nil {
+		t.Fatalf("GET: %v", err)
+	}
+	resp.Body.Close()
+
+	spans := sr.Ended()
+	if len(spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(spans))
+	}
+	if want := "GET " + strings.TrimPrefix(srv.URL, "http://") + "/token"; spans[0].Name() != want {
+		t.Errorf("Expected span %q, got %q", want, spans[0].Name())
+	}
+}
+
+func TestSetup_None(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.Tracing{Exporter: config.TraceExporterNone}, config.EnvTest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := shutdown(context.Background()); err != nil {
+		t.Errorf("Unexpected shutdown error: %v", err)
+	}
+}